@@ -1,11 +1,9 @@
 package goftd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-
-	"github.com/golang/glog"
 )
 
 /*
@@ -113,46 +111,66 @@ func (a *AccessPolicy) Reference() *ReferenceObject {
 	return &r
 }
 
-// GetAccessPolicies Get a list of access policies
+// GetAccessPolicies Get a list of access policies, a limit of 0 returns all of them
 func (f *FTD) GetAccessPolicies(limit int) ([]*AccessPolicy, error) {
-	var err error
+	return f.GetAccessPoliciesContext(context.Background(), limit)
+}
 
-	filter := make(map[string]string)
-	filter["limit"] = strconv.Itoa(limit)
+// GetAccessPoliciesContext Same as GetAccessPolicies, ctx cancels the requests
+func (f *FTD) GetAccessPoliciesContext(ctx context.Context, limit int) ([]*AccessPolicy, error) {
+	var err error
+	var retval []*AccessPolicy
 
-	data, err := f.Get("policy/accesspolicies", filter)
+	err = f.iterAccessPolicies(ctx, limit, func(a *AccessPolicy) error {
+		retval = append(retval, a)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var v struct {
-		Items []*AccessPolicy `json:"items"`
-	}
+	return retval, nil
+}
 
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+// IterAccessPolicies Calls fn for every access policy, fetching one page at a time
+func (f *FTD) IterAccessPolicies(ctx context.Context, fn func(*AccessPolicy) error) error {
+	return f.iterAccessPolicies(ctx, 0, fn)
+}
+
+func (f *FTD) iterAccessPolicies(ctx context.Context, limit int, fn func(*AccessPolicy) error) error {
+	return f.iterate(ctx, apiAccessPoliciesEndpoint, nil, limit, func(item json.RawMessage) error {
+		var a *AccessPolicy
+
+		err := json.Unmarshal(item, &a)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	return v.Items, nil
+		return fn(a)
+	})
 }
 
 // ModifyAccessPolicy Modify access policy
 func (f *FTD) ModifyAccessPolicy(n *AccessPolicy, policy string) error {
+	return f.ModifyAccessPolicyContext(context.Background(), n, policy)
+}
+
+// ModifyAccessPolicyContext Same as ModifyAccessPolicy, ctx cancels the requests
+func (f *FTD) ModifyAccessPolicyContext(ctx context.Context, n *AccessPolicy, policy string) error {
 	var err error
 
 	// Define expected type for this object
 	n.Type = "accesspolicy"
 	n.DefaultAction.Type = "accessdefaultaction"
 
-	endpoint := fmt.Sprintf("policy/accesspolicies/%s", policy)
-	data, err := f.Put(endpoint, n)
+	endpoint := fmt.Sprintf("%s/%s", apiAccessPoliciesEndpoint, policy)
+	data, err := f.PutContext(ctx, endpoint, n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -160,7 +178,7 @@ func (f *FTD) ModifyAccessPolicy(n *AccessPolicy, policy string) error {
 	err = json.Unmarshal(data, &n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
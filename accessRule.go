@@ -1,14 +1,13 @@
 package goftd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
-
-	"github.com/golang/glog"
 )
 
-// AccessRule Access Rule Object
+// AccessRule Access Rule Object, the source and destination networks hold network objects, groups or geolocations
 type AccessRule struct {
 	ReferenceObject
 	RuleID              int                `json:"ruleId,omitempty"`
@@ -26,6 +25,8 @@ type AccessRule struct {
 	FilePolicy          *ReferenceObject   `json:"filePolicy,omitempty"`
 	LogFiles            bool               `json:"logFiles,omitempty"`
 	SyslogServer        *ReferenceObject   `json:"syslogServer,omitempty"`
+	EmbeddedAppFilter   *EmbeddedAppFilter `json:"embeddedAppFilter,omitempty"`
+	URLFilter           *EmbeddedURLFilter `json:"urlFilter,omitempty"`
 	Links               *Links             `json:"links,omitempty"`
 	parent              string
 }
@@ -42,73 +43,166 @@ func (a *AccessRule) Reference() *ReferenceObject {
 	return &r
 }
 
-// GetAccessRules Get a list of access rules
+// Policy Returns the ID of the policy the rule belongs to
+func (a *AccessRule) Policy() string {
+	return a.parent
+}
+
+// GetAccessRules Get a list of access rules, a limit of 0 returns all of them
 func (f *FTD) GetAccessRules(policy string, limit int) ([]*AccessRule, error) {
-	var err error
+	return f.GetAccessRulesContext(context.Background(), policy, limit)
+}
 
-	filter := make(map[string]string)
-	filter["limit"] = strconv.Itoa(limit)
+// GetAccessRulesContext Same as GetAccessRules, ctx cancels the requests
+func (f *FTD) GetAccessRulesContext(ctx context.Context, policy string, limit int) ([]*AccessRule, error) {
+	var err error
+	var retval []*AccessRule
 
-	endpoint := fmt.Sprintf("policy/accesspolicies/%s/accessrules", policy)
-	data, err := f.Get(endpoint, filter)
+	err = f.iterAccessRules(ctx, policy, nil, limit, func(a *AccessRule) error {
+		retval = append(retval, a)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var v struct {
-		Items []*AccessRule `json:"items"`
-	}
+	return retval, nil
+}
 
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+// IterAccessRules Calls fn for every access rule of a policy, fetching one page at a time
+func (f *FTD) IterAccessRules(ctx context.Context, policy string, fn func(*AccessRule) error) error {
+	return f.iterAccessRules(ctx, policy, nil, 0, fn)
+}
+
+func (f *FTD) iterAccessRules(ctx context.Context, policy string, query map[string]string, limit int, fn func(*AccessRule) error) error {
+	endpoint := fmt.Sprintf("%s/%s/accessrules", apiAccessPoliciesEndpoint, policy)
+	return f.iterate(ctx, endpoint, query, limit, func(item json.RawMessage) error {
+		var a *AccessRule
+
+		err := json.Unmarshal(item, &a)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	return v.Items, nil
+		a.parent = policy
+		return fn(a)
+	})
 }
 
-func (f *FTD) getAccessRuleBy(filterString, policy string) ([]*AccessRule, error) {
+func (f *FTD) getAccessRuleBy(ctx context.Context, filterString, policy string) ([]*AccessRule, error) {
 	var err error
+	var retval []*AccessRule
 
 	filter := make(map[string]string)
 	filter["filter"] = filterString
 
-	endpoint := fmt.Sprintf("policy/accesspolicies/%s/accessrules", policy)
-	data, err := f.Get(endpoint, filter)
+	err = f.iterAccessRules(ctx, policy, filter, 0, func(a *AccessRule) error {
+		retval = append(retval, a)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var v struct {
-		Items []*AccessRule `json:"items"`
+	return retval, nil
+}
+
+// GetAccessRuleByID Get an access rule of a policy by ID
+func (f *FTD) GetAccessRuleByID(policy, id string) (*AccessRule, error) {
+	return f.GetAccessRuleByIDContext(context.Background(), policy, id)
+}
+
+// GetAccessRuleByIDContext Same as GetAccessRuleByID, ctx cancels the requests
+func (f *FTD) GetAccessRuleByIDContext(ctx context.Context, policy, id string) (*AccessRule, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s/accessrules/%s", apiAccessPoliciesEndpoint, policy, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
 	}
 
+	var v *AccessRule
+
 	err = json.Unmarshal(data, &v)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
 
-	return v.Items, nil
+	v.parent = policy
+	return v, nil
+}
+
+// GetAccessRuleByName Get an access rule of a policy by name
+func (f *FTD) GetAccessRuleByName(policy, name string) (*AccessRule, error) {
+	return f.GetAccessRuleByNameContext(context.Background(), policy, name)
+}
+
+// GetAccessRuleByNameContext Same as GetAccessRuleByName, ctx cancels the requests
+func (f *FTD) GetAccessRuleByNameContext(ctx context.Context, policy, name string) (*AccessRule, error) {
+	rules, err := f.getAccessRuleBy(ctx, fmt.Sprintf("name:%s", name), policy)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	// The filter matches substrings, keep the exact match
+	for i := range rules {
+		if rules[i].Name == name {
+			return rules[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("access rule %s: %w", name, ErrNotFound)
 }
 
 // CreateAccessRule Create a new access rule
 func (f *FTD) CreateAccessRule(n *AccessRule, policy string) error {
+	return f.CreateAccessRuleContext(context.Background(), n, policy)
+}
+
+// CreateAccessRuleContext Same as CreateAccessRule, ctx cancels the requests
+func (f *FTD) CreateAccessRuleContext(ctx context.Context, n *AccessRule, policy string) error {
+	return f.createAccessRule(ctx, n, policy, nil)
+}
+
+// CreateAccessRuleAt Create a new access rule at position at of the policy, starting from 0
+func (f *FTD) CreateAccessRuleAt(n *AccessRule, policy string, at int) error {
+	return f.CreateAccessRuleAtContext(context.Background(), n, policy, at)
+}
+
+// CreateAccessRuleAtContext Same as CreateAccessRuleAt, ctx cancels the requests
+func (f *FTD) CreateAccessRuleAtContext(ctx context.Context, n *AccessRule, policy string, at int) error {
+	query := make(map[string]string)
+	query["at"] = strconv.Itoa(at)
+
+	return f.createAccessRule(ctx, n, policy, query)
+}
+
+func (f *FTD) createAccessRule(ctx context.Context, n *AccessRule, policy string, query map[string]string) error {
 	var err error
 
 	// Define expected type for this object
 	n.Type = "accessrule"
 
-	endpoint := fmt.Sprintf("policy/accesspolicies/%s/accessrules", policy)
-	data, err := f.Post(endpoint, n)
+	r := requestParameters{
+		FTDRequest: n,
+		URIQuery:   query,
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/accessrules", apiAccessPoliciesEndpoint, policy)
+	data, err := f.request(ctx, endpoint, apiPOST, &r)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -116,7 +210,7 @@ func (f *FTD) CreateAccessRule(n *AccessRule, policy string) error {
 	err = json.Unmarshal(data, &n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -126,15 +220,131 @@ func (f *FTD) CreateAccessRule(n *AccessRule, policy string) error {
 	return nil
 }
 
+// CreateAccessRuleWithDuplicateAction Same as CreateAccessRule, duplicateAction decides what happens
+// when a rule with the same name exists in the policy, like for CreateNetworkObject
+func (f *FTD) CreateAccessRuleWithDuplicateAction(n *AccessRule, policy string, duplicateAction int) error {
+	return f.CreateAccessRuleWithDuplicateActionContext(context.Background(), n, policy, duplicateAction)
+}
+
+// CreateAccessRuleWithDuplicateActionContext Same as CreateAccessRuleWithDuplicateAction, ctx cancels the requests
+func (f *FTD) CreateAccessRuleWithDuplicateActionContext(ctx context.Context, n *AccessRule, policy string, duplicateAction int) error {
+	var err error
+
+	err = f.createAccessRule(ctx, n, policy, nil)
+	if err == nil {
+		return nil
+	}
+
+	if !IsDuplicate(err) || duplicateAction == DuplicateActionError {
+		return err
+	}
+
+	if f.debug {
+		f.logger.Warningf("This is a duplicate\n")
+	}
+
+	o, err := f.GetAccessRuleByNameContext(ctx, policy, n.Name)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	switch duplicateAction {
+	case DuplicateActionReplace:
+		n.ID = o.ID
+		n.Version = o.Version
+		n.parent = o.parent
+
+		return f.UpdateAccessRuleContext(ctx, n)
+	}
+
+	*n = *o
+	return nil
+}
+
+// UpdateAccessRule Updates an access rule, its position is unchanged. n must come from the device, e.g. from GetAccessRuleByID
+func (f *FTD) UpdateAccessRule(n *AccessRule) error {
+	return f.UpdateAccessRuleContext(context.Background(), n)
+}
+
+// UpdateAccessRuleContext Same as UpdateAccessRule, ctx cancels the requests
+func (f *FTD) UpdateAccessRuleContext(ctx context.Context, n *AccessRule) error {
+	return f.updateAccessRule(ctx, n, nil)
+}
+
+// MoveAccessRule Moves an access rule to position at of its policy, starting from 0
+func (f *FTD) MoveAccessRule(n *AccessRule, at int) error {
+	return f.MoveAccessRuleContext(context.Background(), n, at)
+}
+
+// MoveAccessRuleContext Same as MoveAccessRule, ctx cancels the requests
+func (f *FTD) MoveAccessRuleContext(ctx context.Context, n *AccessRule, at int) error {
+	query := make(map[string]string)
+	query["at"] = strconv.Itoa(at)
+
+	return f.updateAccessRule(ctx, n, query)
+}
+
+func (f *FTD) updateAccessRule(ctx context.Context, n *AccessRule, query map[string]string) error {
+	var err error
+
+	r := requestParameters{
+		FTDRequest: n,
+		URIQuery:   query,
+	}
+
+	if n.parent == "" {
+		err = errNoParent("access rule", n.ID, "policy", "GetAccessRuleByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/accessrules/%s", apiAccessPoliciesEndpoint, n.parent, n.ID)
+	data, err := f.request(ctx, endpoint, apiPUT, &r)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
 // DeleteAccessRule Delete an access rule
 func (f *FTD) DeleteAccessRule(n *AccessRule) error {
+	return f.DeleteAccessRuleContext(context.Background(), n)
+}
+
+// DeleteAccessRuleContext Same as DeleteAccessRule, ctx cancels the requests
+func (f *FTD) DeleteAccessRuleContext(ctx context.Context, n *AccessRule) error {
 	var err error
 
-	endpoint := fmt.Sprintf("policy/accesspolicies/%s/accessrules/%s", n.parent, n.ID)
-	err = f.Delete(endpoint)
+	if n.parent == "" {
+		err = errNoParent("access rule", n.ID, "policy", "GetAccessRuleByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/accessrules/%s", apiAccessPoliciesEndpoint, n.parent, n.ID)
+	err = f.DeleteContext(ctx, endpoint)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -0,0 +1,211 @@
+package goftd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// InsertAccessRuleBefore Create a new access rule right before ref, in the policy of ref
+func (f *FTD) InsertAccessRuleBefore(n, ref *AccessRule) error {
+	return f.InsertAccessRuleBeforeContext(context.Background(), n, ref)
+}
+
+// InsertAccessRuleBeforeContext Same as InsertAccessRuleBefore, ctx cancels the requests
+func (f *FTD) InsertAccessRuleBeforeContext(ctx context.Context, n, ref *AccessRule) error {
+	return f.insertAccessRule(ctx, n, ref, 0)
+}
+
+// InsertAccessRuleAfter Create a new access rule right after ref, in the policy of ref
+func (f *FTD) InsertAccessRuleAfter(n, ref *AccessRule) error {
+	return f.InsertAccessRuleAfterContext(context.Background(), n, ref)
+}
+
+// InsertAccessRuleAfterContext Same as InsertAccessRuleAfter, ctx cancels the requests
+func (f *FTD) InsertAccessRuleAfterContext(ctx context.Context, n, ref *AccessRule) error {
+	return f.insertAccessRule(ctx, n, ref, 1)
+}
+
+func (f *FTD) insertAccessRule(ctx context.Context, n, ref *AccessRule, offset int) error {
+	at, err := f.accessRulePosition(ctx, ref)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return f.CreateAccessRuleAtContext(ctx, n, ref.parent, at+offset)
+}
+
+// accessRulePosition Returns the current position of a rule in its policy
+func (f *FTD) accessRulePosition(ctx context.Context, a *AccessRule) (int, error) {
+	if a.parent == "" {
+		return -1, errNoParent("access rule", a.ID, "policy", "GetAccessRuleByID")
+	}
+
+	at := -1
+	i := 0
+
+	err := f.iterAccessRules(ctx, a.parent, nil, 0, func(r *AccessRule) error {
+		if r.ID == a.ID {
+			at = i
+			return errStopIteration
+		}
+		i++
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	if at < 0 {
+		return -1, fmt.Errorf("access rule %s in policy %s: %w", a.Name, a.parent, ErrNotFound)
+	}
+
+	return at, nil
+}
+
+// ReorderAccessRules Reorders the rules of a policy to match ids, which must list every rule of the policy.
+// Rules already in the right relative order stay in place so the number of moves is minimal.
+func (f *FTD) ReorderAccessRules(policy string, ids []string) error {
+	return f.ReorderAccessRulesContext(context.Background(), policy, ids)
+}
+
+// ReorderAccessRulesContext Same as ReorderAccessRules, ctx cancels the requests
+func (f *FTD) ReorderAccessRulesContext(ctx context.Context, policy string, ids []string) error {
+	rules, err := f.GetAccessRulesContext(ctx, policy, 0)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	current := make([]string, len(rules))
+	byID := make(map[string]*AccessRule)
+	for i := range rules {
+		current[i] = rules[i].ID
+		byID[rules[i].ID] = rules[i]
+	}
+
+	moves, err := planMoves(current, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range moves {
+		err = f.MoveAccessRuleContext(ctx, byID[m.id], m.at)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// move Moves the item id to position at
+type move struct {
+	id string
+	at int
+}
+
+// planMoves Returns the moves turning current into desired. Items of the longest subsequence
+// already in the desired order stay, every other item is moved right after its desired predecessor.
+func planMoves(current, desired []string) ([]move, error) {
+	if len(current) != len(desired) {
+		return nil, fmt.Errorf("expecting %d rules, got %d", len(current), len(desired))
+	}
+
+	position := make(map[string]int)
+	for i, id := range current {
+		position[id] = i
+	}
+
+	seen := make(map[string]bool)
+	positions := make([]int, len(desired))
+	for i, id := range desired {
+		p, ok := position[id]
+		if !ok {
+			return nil, fmt.Errorf("rule %s: %w", id, ErrNotFound)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("rule %s is listed twice", id)
+		}
+		seen[id] = true
+		positions[i] = p
+	}
+
+	stay := longestIncreasing(positions)
+
+	var moves []move
+	sim := append([]string(nil), current...)
+	for i, id := range desired {
+		if stay[i] {
+			continue
+		}
+
+		sim = removeID(sim, id)
+
+		at := 0
+		if i > 0 {
+			at = indexOf(sim, desired[i-1]) + 1
+		}
+
+		sim = append(sim[:at], append([]string{id}, sim[at:]...)...)
+		moves = append(moves, move{id: id, at: at})
+	}
+
+	return moves, nil
+}
+
+// longestIncreasing Returns which items of s belong to one of its longest increasing subsequences
+func longestIncreasing(s []int) []bool {
+	// tails[k] index in s of the smallest tail of an increasing subsequence of length k+1
+	var tails []int
+	prev := make([]int, len(s))
+
+	for i, v := range s {
+		k := sort.Search(len(tails), func(j int) bool { return s[tails[j]] >= v })
+
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	retval := make([]bool, len(s))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			retval[i] = true
+		}
+	}
+
+	return retval
+}
+
+// removeID Returns s without id
+func removeID(s []string, id string) []string {
+	i := indexOf(s, id)
+	return append(s[:i], s[i+1:]...)
+}
+
+// indexOf Returns the position of id in s, or -1
+func indexOf(s []string, id string) int {
+	for i := range s {
+		if s[i] == id {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,51 @@
+package goftd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanMoves(t *testing.T) {
+	tests := []struct {
+		current []string
+		desired []string
+		moves   int
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{[]string{"a", "b", "c"}, []string{"b", "c", "a"}, 1},
+		{[]string{"a", "b", "c", "d"}, []string{"d", "c", "b", "a"}, 3},
+		{[]string{"a", "b", "c", "d", "e"}, []string{"a", "e", "b", "c", "d"}, 1},
+		{[]string{"a", "b", "c", "d", "e"}, []string{"c", "a", "e", "b", "d"}, 2},
+		{nil, nil, 0},
+	}
+
+	for _, tt := range tests {
+		moves, err := planMoves(tt.current, tt.desired)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			continue
+		}
+
+		if len(moves) != tt.moves {
+			t.Errorf("%v to %v: expecting %d moves, got %d\n", tt.current, tt.desired, tt.moves, len(moves))
+		}
+
+		// Replay the moves the way the device applies them
+		s := append([]string(nil), tt.current...)
+		for _, m := range moves {
+			s = removeID(s, m.id)
+			s = append(s[:m.at], append([]string{m.id}, s[m.at:]...)...)
+		}
+
+		if len(s) > 0 && !reflect.DeepEqual(s, tt.desired) {
+			t.Errorf("%v to %v: got %v\n", tt.current, tt.desired, s)
+		}
+	}
+
+	for _, desired := range [][]string{{"a", "b"}, {"a", "b", "d"}, {"a", "a", "b"}} {
+		_, err := planMoves([]string{"a", "b", "c"}, desired)
+		if err == nil {
+			t.Errorf("%v: expecting an error\n", desired)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package goftd
 
 import (
+	"encoding/json"
+	"net/http"
 	"testing"
 )
 
@@ -145,3 +147,111 @@ func TestCombinedCreateAccessRules(t *testing.T) {
 	tearDownTestAccessRuleObjects(t)
 
 }
+
+func TestAccessRuleLifecycle(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	a := new(AccessRule)
+	a.Name = "testPolicy002"
+	a.RuleAction = RuleActionPermit
+	a.EventLogAction = LogActionNone
+
+	err = ftd.CreateAccessRuleWithDuplicateAction(a, "default", DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	a2, err := ftd.GetAccessRuleByName("default", a.Name)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if a2.ID != a.ID || a2.Policy() != "default" {
+		t.Errorf("expected ID %s in default, got %s in %s\n", a.ID, a2.ID, a2.Policy())
+	}
+
+	a2.EventLogAction = LogActionFlowStart
+	err = ftd.UpdateAccessRule(a2)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	a3, err := ftd.GetAccessRuleByID("default", a.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if a3.EventLogAction != LogActionFlowStart {
+		t.Errorf("expecting %s, got %s\n", LogActionFlowStart, a3.EventLogAction)
+	}
+
+	// Rules returned by a list can be deleted
+	rules, err := ftd.GetAccessRules("default", 0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	for _, r := range rules {
+		if r.ID == a.ID {
+			err = ftd.DeleteAccessRule(r)
+			if err != nil {
+				t.Errorf("error: %s\n", err)
+			}
+		}
+	}
+
+	_, err = ftd.GetAccessRuleByName("default", a.Name)
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+}
+
+func TestCreateAccessRuleDuplicateReplace(t *testing.T) {
+	var put *AccessRule
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":{"severity":"ERROR","key":"Validation","messages":[{"description":"duplicate","code":"duplicateName"}]}}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"items":[{"id":"rule1","version":"v1","name":"testPolicy0010","type":"accessrule"},{"id":"rule2","version":"v2","name":"testPolicy001","type":"accessrule"}]}`))
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&put)
+			put.Version = "v3"
+			json.NewEncoder(w).Encode(put)
+		}
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+
+	a := new(AccessRule)
+	a.Name = "testPolicy001"
+	a.RuleAction = RuleActionPermit
+
+	err := ftd.CreateAccessRuleWithDuplicateAction(a, "default", DuplicateActionError)
+	if !IsDuplicate(err) {
+		t.Errorf("expecting duplicate, got %v\n", err)
+	}
+
+	err = ftd.CreateAccessRuleWithDuplicateAction(a, "default", DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if put == nil || put.ID != "rule2" || put.RuleAction != RuleActionPermit {
+		t.Errorf("unexpected update %+v\n", put)
+	}
+
+	if a.ID != "rule2" || a.Version != "v3" || a.Policy() != "default" {
+		t.Errorf("rule is not populated correctly: %+v\n", a)
+	}
+}
@@ -0,0 +1,287 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// EmbeddedAppFilter Applications matched by an access rule, listed or selected by filters
+type EmbeddedAppFilter struct {
+	Applications       []*ReferenceObject `json:"applications,omitempty"`
+	ApplicationFilters []*ReferenceObject `json:"applicationFilters,omitempty"`
+	Type               string             `json:"type"`
+}
+
+// NewEmbeddedAppFilter Returns an application filter matching apps
+func NewEmbeddedAppFilter(apps ...*Application) *EmbeddedAppFilter {
+	r := EmbeddedAppFilter{
+		Type: "embeddedappfilter",
+	}
+
+	for _, a := range apps {
+		r.Applications = append(r.Applications, a.Reference())
+	}
+
+	return &r
+}
+
+// NewEmbeddedAppFilterFromNames Returns an application filter matching the applications named names
+func (f *FTD) NewEmbeddedAppFilterFromNames(names ...string) (*EmbeddedAppFilter, error) {
+	return f.NewEmbeddedAppFilterFromNamesContext(context.Background(), names...)
+}
+
+// NewEmbeddedAppFilterFromNamesContext Same as NewEmbeddedAppFilterFromNames, ctx cancels the requests
+func (f *FTD) NewEmbeddedAppFilterFromNamesContext(ctx context.Context, names ...string) (*EmbeddedAppFilter, error) {
+	var apps []*Application
+
+	for _, name := range names {
+		a, err := f.GetApplicationByNameContext(ctx, name)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return nil, err
+		}
+		apps = append(apps, a)
+	}
+
+	return NewEmbeddedAppFilter(apps...), nil
+}
+
+// Application An application identified by the device, e.g. Facebook
+type Application struct {
+	ReferenceObject
+	AppID       int                `json:"appId,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Categories  []*ReferenceObject `json:"applicationCategories,omitempty"`
+	Tags        []*ReferenceObject `json:"applicationTags,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
+	Links       *Links             `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (a *Application) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      a.ID,
+		Name:    a.Name,
+		Version: a.Version,
+		Type:    a.Type,
+	}
+
+	return &r
+}
+
+// ApplicationCategory A category of applications, e.g. social networking
+type ApplicationCategory struct {
+	ReferenceObject
+	Description string `json:"description,omitempty"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (c *ApplicationCategory) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      c.ID,
+		Name:    c.Name,
+		Version: c.Version,
+		Type:    c.Type,
+	}
+
+	return &r
+}
+
+// ApplicationTag A tag describing applications, e.g. encrypts communications
+type ApplicationTag struct {
+	ReferenceObject
+	Description string `json:"description,omitempty"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (t *ApplicationTag) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      t.ID,
+		Name:    t.Name,
+		Version: t.Version,
+		Type:    t.Type,
+	}
+
+	return &r
+}
+
+// GetApplications Get a list of applications, a limit of 0 returns all of them
+func (f *FTD) GetApplications(limit int) ([]*Application, error) {
+	return f.GetApplicationsContext(context.Background(), limit)
+}
+
+// GetApplicationsContext Same as GetApplications, ctx cancels the requests
+func (f *FTD) GetApplicationsContext(ctx context.Context, limit int) ([]*Application, error) {
+	var err error
+	var retval []*Application
+
+	err = f.iterApplications(ctx, limit, func(v *Application) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterApplications Calls fn for every application, fetching one page at a time
+func (f *FTD) IterApplications(ctx context.Context, fn func(*Application) error) error {
+	return f.iterApplications(ctx, 0, fn)
+}
+
+func (f *FTD) iterApplications(ctx context.Context, limit int, fn func(*Application) error) error {
+	return f.iterate(ctx, apiApplicationsEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *Application
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetApplicationByName Get a application by name
+func (f *FTD) GetApplicationByName(name string) (*Application, error) {
+	return f.GetApplicationByNameContext(context.Background(), name)
+}
+
+// GetApplicationByNameContext Same as GetApplicationByName, ctx cancels the requests
+func (f *FTD) GetApplicationByNameContext(ctx context.Context, name string) (*Application, error) {
+	var v *Application
+
+	err := f.findByName(ctx, apiApplicationsEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetApplicationCategories Get a list of application categories, a limit of 0 returns all of them
+func (f *FTD) GetApplicationCategories(limit int) ([]*ApplicationCategory, error) {
+	return f.GetApplicationCategoriesContext(context.Background(), limit)
+}
+
+// GetApplicationCategoriesContext Same as GetApplicationCategories, ctx cancels the requests
+func (f *FTD) GetApplicationCategoriesContext(ctx context.Context, limit int) ([]*ApplicationCategory, error) {
+	var err error
+	var retval []*ApplicationCategory
+
+	err = f.iterApplicationCategories(ctx, limit, func(v *ApplicationCategory) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterApplicationCategories Calls fn for every application category, fetching one page at a time
+func (f *FTD) IterApplicationCategories(ctx context.Context, fn func(*ApplicationCategory) error) error {
+	return f.iterApplicationCategories(ctx, 0, fn)
+}
+
+func (f *FTD) iterApplicationCategories(ctx context.Context, limit int, fn func(*ApplicationCategory) error) error {
+	return f.iterate(ctx, apiApplicationCategoriesEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *ApplicationCategory
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetApplicationCategoryByName Get a application category by name
+func (f *FTD) GetApplicationCategoryByName(name string) (*ApplicationCategory, error) {
+	return f.GetApplicationCategoryByNameContext(context.Background(), name)
+}
+
+// GetApplicationCategoryByNameContext Same as GetApplicationCategoryByName, ctx cancels the requests
+func (f *FTD) GetApplicationCategoryByNameContext(ctx context.Context, name string) (*ApplicationCategory, error) {
+	var v *ApplicationCategory
+
+	err := f.findByName(ctx, apiApplicationCategoriesEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetApplicationTags Get a list of application tags, a limit of 0 returns all of them
+func (f *FTD) GetApplicationTags(limit int) ([]*ApplicationTag, error) {
+	return f.GetApplicationTagsContext(context.Background(), limit)
+}
+
+// GetApplicationTagsContext Same as GetApplicationTags, ctx cancels the requests
+func (f *FTD) GetApplicationTagsContext(ctx context.Context, limit int) ([]*ApplicationTag, error) {
+	var err error
+	var retval []*ApplicationTag
+
+	err = f.iterApplicationTags(ctx, limit, func(v *ApplicationTag) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterApplicationTags Calls fn for every application tag, fetching one page at a time
+func (f *FTD) IterApplicationTags(ctx context.Context, fn func(*ApplicationTag) error) error {
+	return f.iterApplicationTags(ctx, 0, fn)
+}
+
+func (f *FTD) iterApplicationTags(ctx context.Context, limit int, fn func(*ApplicationTag) error) error {
+	return f.iterate(ctx, apiApplicationTagsEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *ApplicationTag
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetApplicationTagByName Get a application tag by name
+func (f *FTD) GetApplicationTagByName(name string) (*ApplicationTag, error) {
+	return f.GetApplicationTagByNameContext(context.Background(), name)
+}
+
+// GetApplicationTagByNameContext Same as GetApplicationTagByName, ctx cancels the requests
+func (f *FTD) GetApplicationTagByNameContext(ctx context.Context, name string) (*ApplicationTag, error) {
+	var v *ApplicationTag
+
+	err := f.findByName(ctx, apiApplicationTagsEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
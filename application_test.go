@@ -0,0 +1,36 @@
+package goftd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewEmbeddedAppFilterFromNames(t *testing.T) {
+	var filter string
+	h := func(w http.ResponseWriter, r *http.Request) {
+		filter = r.URL.Query().Get("filter")
+		w.Write([]byte(`{"items":[{"id":"app1","name":"Facebook Apps","type":"application"},{"id":"app2","name":"Facebook","appId":629,"type":"application"}]}`))
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+
+	e, err := ftd.NewEmbeddedAppFilterFromNames("Facebook")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if filter != "name:Facebook" {
+		t.Errorf("unexpected filter %s\n", filter)
+	}
+
+	if e.Type != "embeddedappfilter" || len(e.Applications) != 1 || e.Applications[0].ID != "app2" {
+		t.Errorf("unexpected filter %+v\n", e)
+	}
+
+	_, err = ftd.GetApplicationByName("Twitter")
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+}
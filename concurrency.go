@@ -0,0 +1,301 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// VersionConflictError Returned when an update is rejected because the object was modified since it was read,
+// e.g. by someone in the UI. Fetch it again and reapply the change, see UpdateWithRetry.
+// errors.Is(err, ErrConflict) matches it.
+type VersionConflictError struct {
+	ID   string
+	Name string
+	Type string
+	// Version the update was based on
+	Version string
+	Err     *APIError
+}
+
+func (ve *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s (%s) was modified since version %s: %s", ve.Type, ve.Name, ve.ID, ve.Version, ve.Err)
+}
+
+// Unwrap Returns the API error
+func (ve *VersionConflictError) Unwrap() error {
+	return ve.Err
+}
+
+// versionConflict Returns err as a *VersionConflictError when it is a version mismatch on the update of obj
+func versionConflict(obj interface{}, err error) error {
+	var ae *APIError
+	if !errors.As(err, &ae) || !ae.Is(ErrConflict) {
+		return err
+	}
+
+	// obj embeds a ReferenceObject, decode its identity
+	var r ReferenceObject
+	data, jerr := json.Marshal(obj)
+	if jerr == nil {
+		json.Unmarshal(data, &r)
+	}
+
+	return &VersionConflictError{
+		ID:      r.ID,
+		Name:    r.Name,
+		Type:    r.Type,
+		Version: r.Version,
+		Err:     ae,
+	}
+}
+
+// UpdateWithRetry Applies mutate to obj and updates it on the device. When the version of obj is out of date,
+// obj is fetched again, mutate is applied again to the fresh copy and the update is retried, up to maxUpdateAttempts times.
+// obj is a pointer to an object read from the device, e.g. a *NetworkObject or an *AccessRule,
+// mutate changes obj in place and must not keep state between calls.
+func (f *FTD) UpdateWithRetry(obj interface{}, mutate func() error) error {
+	return f.UpdateWithRetryContext(context.Background(), obj, mutate)
+}
+
+// UpdateWithRetryContext Same as UpdateWithRetry, ctx cancels the requests
+func (f *FTD) UpdateWithRetryContext(ctx context.Context, obj interface{}, mutate func() error) error {
+	reload, update, err := f.versioned(obj)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = mutate()
+		if err != nil {
+			return err
+		}
+
+		err = update(ctx)
+		if err == nil || !IsConflict(err) || attempt >= maxUpdateAttempts {
+			return err
+		}
+
+		if f.debug {
+			f.logger.Warningf("update conflict (attempt %d), reloading: %s\n", attempt, err)
+		}
+
+		err = reload(ctx)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+}
+
+// versioned Returns the functions reloading obj from the device and updating it
+func (f *FTD) versioned(obj interface{}) (reload func(context.Context) error, update func(context.Context) error, err error) {
+	switch t := obj.(type) {
+	case *NetworkObject:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetNetworkObjectByIDContext(ctx, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateNetworkObjectContext(ctx, t)
+		}
+	case *NetworkObjectGroup:
+		reload = func(ctx context.Context) error {
+			var o NetworkObjectGroup
+			err := f.getByID(ctx, apiNetworkGroupsEndpoint, t.ID, &o)
+			if err == nil {
+				*t = o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateNetworkObjectGroupContext(ctx, t)
+		}
+	case *PortObject:
+		reload = func(ctx context.Context) error {
+			var o *PortObject
+			var err error
+			if t.Type == TypeUDPPortObject {
+				o, err = f.GetUDPPortObjectByIDContext(ctx, t.ID)
+			} else {
+				o, err = f.GetTCPPortObjectByIDContext(ctx, t.ID)
+			}
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdatePortObjectContext(ctx, t)
+		}
+	case *PortObjectGroup:
+		reload = func(ctx context.Context) error {
+			var o PortObjectGroup
+			err := f.getByID(ctx, apiPortObjectGroupsEndpoint, t.ID, &o)
+			if err == nil {
+				*t = o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdatePortObjectGroupContext(ctx, t)
+		}
+	case *ICMPPortObject:
+		reload = func(ctx context.Context) error {
+			endpoint, err := icmpPortEndpoint(t.Type)
+			if err != nil {
+				return err
+			}
+
+			var o ICMPPortObject
+			err = f.getByID(ctx, endpoint, t.ID, &o)
+			if err == nil {
+				*t = o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateICMPPortObjectContext(ctx, t)
+		}
+	case *ProtocolObject:
+		reload = func(ctx context.Context) error {
+			var o ProtocolObject
+			err := f.getByID(ctx, apiProtocolObjectsEndpoint, t.ID, &o)
+			if err == nil {
+				*t = o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateProtocolObjectContext(ctx, t)
+		}
+	case *SecurityZone:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetSecurityZoneByIDContext(ctx, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateSecurityZoneContext(ctx, t)
+		}
+	case *AccessPolicy:
+		reload = func(ctx context.Context) error {
+			var o AccessPolicy
+			err := f.getByID(ctx, apiAccessPoliciesEndpoint, t.ID, &o)
+			if err == nil {
+				*t = o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.ModifyAccessPolicyContext(ctx, t, t.ID)
+		}
+	case *AccessRule:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetAccessRuleByIDContext(ctx, t.parent, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateAccessRuleContext(ctx, t)
+		}
+	case *ObjectNATRule:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetObjectNATRuleByIDContext(ctx, t.parent, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateObjectNATRuleContext(ctx, t)
+		}
+	case *ManualNATRule:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetManualNATRuleByIDContext(ctx, t.parent, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateManualNATRuleContext(ctx, t)
+		}
+	case *StaticRouteEntry:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetStaticRouteEntryByIDContext(ctx, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateStaticRouteEntryContext(ctx, t)
+		}
+	case *PhysicalInterface:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetPhysicalInterfaceByIDContext(ctx, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdatePhysicalInterfaceContext(ctx, t)
+		}
+	case *SubInterface:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetSubInterfaceByIDContext(ctx, t.parent, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateSubInterfaceContext(ctx, t)
+		}
+	case *VLANInterface:
+		reload = func(ctx context.Context) error {
+			o, err := f.GetVLANInterfaceByIDContext(ctx, t.ID)
+			if err == nil {
+				*t = *o
+			}
+			return err
+		}
+		update = func(ctx context.Context) error {
+			return f.UpdateVLANInterfaceContext(ctx, t)
+		}
+	default:
+		return nil, nil, fmt.Errorf("can't update objects of type %T", obj)
+	}
+
+	return reload, update, nil
+}
+
+// getByID Gets the item id of endpoint and unmarshals it into v
+func (f *FTD) getByID(ctx context.Context, endpoint, id string, v interface{}) error {
+	data, err := f.GetContext(ctx, fmt.Sprintf("%s/%s", endpoint, id), nil)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package goftd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateWithRetry(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n, err := NewHostObject("testObj001", "192.0.2.1")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	err = ftd.CreateNetworkObject(n, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObject(n)
+
+	// Someone else modifies the object after it was read
+	other := *n
+	other.Value = "192.0.2.2"
+	err = ftd.UpdateNetworkObject(&other)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	stale := *n
+	stale.Description = "stale"
+	err = ftd.UpdateNetworkObject(&stale)
+
+	var ve *VersionConflictError
+	if !errors.As(err, &ve) || !IsConflict(err) {
+		t.Errorf("expecting a version conflict, got %v\n", err)
+	} else if ve.ID != n.ID || ve.Version != n.Version {
+		t.Errorf("expecting %s version %s, got %s version %s\n", n.ID, n.Version, ve.ID, ve.Version)
+	}
+
+	calls := 0
+	err = ftd.UpdateWithRetry(n, func() error {
+		calls++
+		n.Description = "updated"
+		return nil
+	})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if calls != 2 {
+		t.Errorf("expecting mutate to be applied twice, got %d\n", calls)
+	}
+
+	if n.Value != other.Value || n.Description != "updated" || n.Version == other.Version {
+		t.Errorf("expecting the concurrent change to be kept: %+v\n", n)
+	}
+
+	err = ftd.UpdateWithRetry(&struct{}{}, func() error { return nil })
+	if err == nil {
+		t.Errorf("expecting an error for an unsupported type\n")
+	}
+}
@@ -1,26 +1,92 @@
 package goftd
 
+import "time"
+
 const (
 	grantTypePassword string = "password"
 	grantTypeCustom   string = "custom_token"
+	grantTypeRefresh  string = "refresh_token"
+	grantTypeRevoke   string = "revoke_token"
+
+	// tokenRefreshMargin refresh the access token this long before it expires
+	tokenRefreshMargin time.Duration = 30 * time.Second
+
+	// deploymentPollInterval poll interval of WaitForDeployment when none is given
+	deploymentPollInterval time.Duration = 5 * time.Second
 
 	apiPOST   string = "POST"
 	apiPUT    string = "PUT"
 	apiDELETE string = "DELETE"
 	apiGET    string = "GET"
 
-	apiBasePath                 string = "api/fdm/v1/"
-	apiTokenEndpoint            string = "fdm/token"
-	apiNetworksEndpoint         string = "object/networks"
-	apiNetworkGroupsEndpoint    string = "object/networkgroups"
-	apiTCPPortObjectsEndpoint   string = "object/tcpports"
-	apiUDPPortObjectsEndpoint   string = "object/udpports"
-	apiPortObjectGroupsEndpoint string = "object/portgroups"
+	apiBasePath                      string = "api/fdm/v1/"
+	apiTokenEndpoint                 string = "fdm/token"
+	apiNetworksEndpoint              string = "object/networks"
+	apiNetworkGroupsEndpoint         string = "object/networkgroups"
+	apiTCPPortObjectsEndpoint        string = "object/tcpports"
+	apiUDPPortObjectsEndpoint        string = "object/udpports"
+	apiPortObjectGroupsEndpoint      string = "object/portgroups"
+	apiICMPv4PortObjectsEndpoint     string = "object/icmpv4ports"
+	apiICMPv6PortObjectsEndpoint     string = "object/icmpv6ports"
+	apiProtocolObjectsEndpoint       string = "object/protocols"
+	apiAccessPoliciesEndpoint        string = "policy/accesspolicies"
+	apiDeployEndpoint                string = "operational/deploy"
+	apiPendingChangesEndpoint        string = "operational/pendingchanges"
+	apiSecurityZonesEndpoint         string = "object/securityzones"
+	apiInterfacesEndpoint            string = "devices/default/interfaces"
+	apiVLANInterfacesEndpoint        string = "devices/default/vlaninterfaces"
+	apiObjectNATPoliciesEndpoint     string = "policy/objectnatpolicies"
+	apiManualNATPoliciesEndpoint     string = "policy/manualnatpolicies"
+	apiStaticRouteEntriesEndpoint    string = "devices/default/routing/virtualrouters/default/staticrouteentries"
+	apiApplicationsEndpoint          string = "object/applications"
+	apiApplicationCategoriesEndpoint string = "object/applicationcategories"
+	apiApplicationTagsEndpoint       string = "object/applicationtags"
+	apiURLCategoriesEndpoint         string = "object/urlcategories"
+	apiURLReputationsEndpoint        string = "object/urlreputation"
+	apiURLObjectsEndpoint            string = "object/urls"
+	apiGeolocationsEndpoint          string = "object/geolocations"
+
+	// apiPageLimit number of items requested per page when walking a list
+	apiPageLimit int = 100
+
+	// maxUpdateAttempts number of times UpdateWithRetry tries an update rejected for its version
+	maxUpdateAttempts int = 5
 
+	// TypeNetworkObject object type network
+	TypeNetworkObject string = "networkobject"
+	// TypeNetworkObjectGroup object type network group
+	TypeNetworkObjectGroup string = "networkobjectgroup"
 	// TypeUDPPortObject object type udp port
 	TypeUDPPortObject string = "udpportobject"
 	// TypeTCPPortObject object type tcp port
 	TypeTCPPortObject string = "tcpportobject"
+	// TypeICMPv4PortObject object type icmpv4 port
+	TypeICMPv4PortObject string = "icmpv4portobject"
+	// TypeICMPv6PortObject object type icmpv6 port
+	TypeICMPv6PortObject string = "icmpv6portobject"
+	// TypeProtocolObject object type protocol
+	TypeProtocolObject string = "protocolobject"
+
+	//NetworkObjectSubTypeHost HOST, a single address
+	NetworkObjectSubTypeHost string = "HOST"
+
+	//NetworkObjectSubTypeNetwork NETWORK, a CIDR
+	NetworkObjectSubTypeNetwork string = "NETWORK"
+
+	//NetworkObjectSubTypeRange RANGE, first-last
+	NetworkObjectSubTypeRange string = "RANGE"
+
+	//NetworkObjectSubTypeFQDN FQDN, a hostname resolved by the device
+	NetworkObjectSubTypeFQDN string = "FQDN"
+
+	//DNSResolutionIPv4Only IPV4_ONLY
+	DNSResolutionIPv4Only string = "IPV4_ONLY"
+
+	//DNSResolutionIPv6Only IPV6_ONLY
+	DNSResolutionIPv6Only string = "IPV6_ONLY"
+
+	//DNSResolutionIPv4AndIPv6 IPV4_AND_IPV6
+	DNSResolutionIPv4AndIPv6 string = "IPV4_AND_IPV6"
 
 	//DuplicateActionError Error on duplicate
 	DuplicateActionError int = 0
@@ -39,4 +105,76 @@ const (
 
 	//RuleActionPermit PERMIT
 	RuleActionPermit string = "PERMIT"
+
+	//DeploymentStateQueued QUEUED
+	DeploymentStateQueued string = "QUEUED"
+
+	//DeploymentStateDeploying DEPLOYING
+	DeploymentStateDeploying string = "DEPLOYING"
+
+	//DeploymentStateDeployed DEPLOYED
+	DeploymentStateDeployed string = "DEPLOYED"
+
+	//DeploymentStateFailed DEPLOY_FAILED
+	DeploymentStateFailed string = "DEPLOY_FAILED"
+
+	//SecurityZoneModeRouted ROUTED
+	SecurityZoneModeRouted string = "ROUTED"
+
+	//SecurityZoneModePassive PASSIVE
+	SecurityZoneModePassive string = "PASSIVE"
+
+	//InterfaceModeRouted ROUTED
+	InterfaceModeRouted string = "ROUTED"
+
+	//InterfaceModePassive PASSIVE
+	InterfaceModePassive string = "PASSIVE"
+
+	//InterfaceModeSwitchPort SWITCHPORT
+	InterfaceModeSwitchPort string = "SWITCHPORT"
+
+	//IPTypeStatic STATIC
+	IPTypeStatic string = "STATIC"
+
+	//IPTypeDHCP DHCP
+	IPTypeDHCP string = "DHCP"
+
+	//ChangeTypeAdd ADD
+	ChangeTypeAdd string = "ADD"
+
+	//ChangeTypeEdit EDIT
+	ChangeTypeEdit string = "EDIT"
+
+	//ChangeTypeDelete DELETE
+	ChangeTypeDelete string = "DELETE"
+
+	//RouteIPTypeIPv4 IPv4
+	RouteIPTypeIPv4 string = "IPv4"
+
+	//RouteIPTypeIPv6 IPv6
+	RouteIPTypeIPv6 string = "IPv6"
+
+	//PlanActionCreate CREATE
+	PlanActionCreate string = "CREATE"
+
+	//PlanActionUpdate UPDATE
+	PlanActionUpdate string = "UPDATE"
+
+	//PlanActionDelete DELETE
+	PlanActionDelete string = "DELETE"
+
+	//NATTypeStatic STATIC
+	NATTypeStatic string = "STATIC"
+
+	//NATTypeDynamic DYNAMIC
+	NATTypeDynamic string = "DYNAMIC"
+
+	//ObjectNATPolicy NGFW-Object-NAT-Policy, the container of the object NAT rules
+	ObjectNATPolicy string = "NGFW-Object-NAT-Policy"
+
+	//ManualNATPolicyBefore NGFW-Before-Auto-NAT-Policy, manual NAT rules evaluated before object NAT
+	ManualNATPolicyBefore string = "NGFW-Before-Auto-NAT-Policy"
+
+	//ManualNATPolicyAfter NGFW-After-Auto-NAT-Policy, manual NAT rules evaluated after object NAT
+	ManualNATPolicyAfter string = "NGFW-After-Auto-NAT-Policy"
 )
@@ -0,0 +1,179 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Deployment A deployment job pushing the pending changes to the device
+type Deployment struct {
+	ReferenceObject
+	State          string   `json:"state,omitempty"`
+	StatusMessage  string   `json:"statusMessage,omitempty"`
+	StatusMessages []string `json:"statusMessages,omitempty"`
+	QueuedTime     int64    `json:"queuedTime,omitempty"`
+	StartTime      int64    `json:"startTime,omitempty"`
+	EndTime        int64    `json:"endTime,omitempty"`
+	Links          *Links   `json:"links,omitempty"`
+}
+
+// Failed Returns true if the deployment ended in error
+func (d *Deployment) Failed() bool {
+	return strings.HasSuffix(d.State, "FAILED")
+}
+
+// Done Returns true once the deployment reached a final state
+func (d *Deployment) Done() bool {
+	return d.State == DeploymentStateDeployed || d.Failed()
+}
+
+// messages Returns every status message reported for the deployment
+func (d *Deployment) messages() []string {
+	var retval []string
+
+	if d.StatusMessage != "" {
+		retval = append(retval, d.StatusMessage)
+	}
+
+	return append(retval, d.StatusMessages...)
+}
+
+// DeploymentError Error returned when a deployment did not succeed
+type DeploymentError struct {
+	ID       string
+	State    string
+	Messages []string
+}
+
+func (de *DeploymentError) Error() string {
+	return fmt.Sprintf("deployment %s is %s with messages %+v", de.ID, de.State, de.Messages)
+}
+
+// Deploy Starts a deployment of the pending changes
+func (f *FTD) Deploy(ctx context.Context) (*Deployment, error) {
+	var err error
+
+	data, err := f.request(ctx, apiDeployEndpoint, apiPOST, nil)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	var v *Deployment
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetDeploymentStatus Get a deployment by ID
+func (f *FTD) GetDeploymentStatus(id string) (*Deployment, error) {
+	return f.GetDeploymentStatusContext(context.Background(), id)
+}
+
+// GetDeploymentStatusContext Same as GetDeploymentStatus, ctx cancels the requests
+func (f *FTD) GetDeploymentStatusContext(ctx context.Context, id string) (*Deployment, error) {
+	return f.getDeployment(ctx, id)
+}
+
+func (f *FTD) getDeployment(ctx context.Context, id string) (*Deployment, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiDeployEndpoint, id)
+	data, err := f.request(ctx, endpoint, apiGET, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *Deployment
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// ListDeployments Get a list of deployments, a limit of 0 returns all of them
+func (f *FTD) ListDeployments(limit int) ([]*Deployment, error) {
+	return f.ListDeploymentsContext(context.Background(), limit)
+}
+
+// ListDeploymentsContext Same as ListDeployments, ctx cancels the requests
+func (f *FTD) ListDeploymentsContext(ctx context.Context, limit int) ([]*Deployment, error) {
+	var err error
+	var retval []*Deployment
+
+	err = f.iterate(ctx, apiDeployEndpoint, nil, limit, func(item json.RawMessage) error {
+		var d *Deployment
+
+		err := json.Unmarshal(item, &d)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		retval = append(retval, d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// WaitForDeployment Polls a deployment every interval until it is DEPLOYED or failed, every 5 seconds when interval isn't positive.
+// A failed deployment is returned along with a *DeploymentError.
+func (f *FTD) WaitForDeployment(ctx context.Context, id string, interval time.Duration) (*Deployment, error) {
+	if interval <= 0 {
+		interval = deploymentPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d, err := f.getDeployment(ctx, id)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return nil, err
+		}
+
+		if d.Done() {
+			if d.Failed() {
+				return d, &DeploymentError{
+					ID:       d.ID,
+					State:    d.State,
+					Messages: d.messages(),
+				}
+			}
+			return d, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return d, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package goftd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeploymentState(t *testing.T) {
+	d := &Deployment{State: DeploymentStateDeploying}
+	if d.Done() {
+		t.Errorf("%s should not be done\n", d.State)
+	}
+
+	d.State = DeploymentStateDeployed
+	if !d.Done() || d.Failed() {
+		t.Errorf("%s should be done without failure\n", d.State)
+	}
+
+	d.State = DeploymentStateFailed
+	if !d.Done() || !d.Failed() {
+		t.Errorf("%s should be done with failure\n", d.State)
+	}
+}
+
+func TestDeploy(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	d, err := ftd.Deploy(ctx)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if d.ID == "" {
+		t.Errorf("ID was not set\n")
+		return
+	}
+
+	d, err = ftd.WaitForDeployment(ctx, d.ID, 5*time.Second)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if d.State != DeploymentStateDeployed {
+		t.Errorf("expecting state %s, got %s\n", DeploymentStateDeployed, d.State)
+	}
+
+	// No interval falls back to the default one
+	d, err = ftd.WaitForDeployment(ctx, d.ID, 0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	ds, err := ftd.ListDeployments(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(ds) < 1 {
+		t.Errorf("expecting at least 1 deployment\n")
+	}
+}
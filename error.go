@@ -2,7 +2,25 @@ package goftd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound the object doesn't exist
+	ErrNotFound = errors.New("not found")
+	// ErrDuplicate an object with the same name or ID already exists
+	ErrDuplicate = errors.New("duplicate")
+	// ErrUnauthorized the credentials or the token were rejected
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrConflict the object was modified since it was read, its version doesn't match
+	ErrConflict = errors.New("conflict")
+	// ErrCycle a group would contain itself, directly or through nested groups
+	ErrCycle = errors.New("nesting cycle")
+	// ErrInUse the object is referenced by another entity, see SafeDelete
+	ErrInUse = errors.New("in use")
 )
 
 // FTDMessage  Error message returned by API
@@ -23,7 +41,87 @@ func (fe FTDError) Error() string {
 	return fmt.Sprintf("%s: %s with messages %+v", fe.Severity, fe.Key, fe.Message)
 }
 
-func parseResponse(bodyText []byte, authenticating bool) (err error) {
+// APIError Error returned by the API for a request, use errors.As to retrieve it
+// and errors.Is with ErrNotFound, ErrDuplicate, ErrUnauthorized or ErrConflict to check it
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	FTDError
+}
+
+func (ae *APIError) Error() string {
+	if ae.Key == "" && len(ae.Message) == 0 {
+		return fmt.Sprintf("%s %s: response code: %d", ae.Method, ae.Endpoint, ae.StatusCode)
+	}
+	return fmt.Sprintf("%s %s: response code: %d: %s", ae.Method, ae.Endpoint, ae.StatusCode, ae.FTDError.Error())
+}
+
+// Is Matches the sentinel errors
+func (ae *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return ae.StatusCode == http.StatusNotFound
+	case ErrDuplicate:
+		return ae.hasCode(func(code string) bool {
+			return code == "duplicateName" || code == "newInstanceWithDuplicateId"
+		})
+	case ErrUnauthorized:
+		return ae.StatusCode == http.StatusUnauthorized || ae.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return ae.StatusCode == http.StatusConflict || ae.hasCode(func(code string) bool {
+			return strings.Contains(strings.ToLower(code), "version")
+		})
+	}
+
+	return false
+}
+
+// hasCode Returns true if one of the messages has a code matching fn
+func (ae *APIError) hasCode(fn func(code string) bool) bool {
+	for _, m := range ae.Message {
+		if fn(m.Code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNotFound Returns true if err is an API error for an object that doesn't exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsDuplicate Returns true if err is an API error for an object that already exists
+func IsDuplicate(err error) bool {
+	return errors.Is(err, ErrDuplicate)
+}
+
+// IsUnauthorized Returns true if err is an API error for rejected credentials
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsConflict Returns true if err is an API error for an object version mismatch, see VersionConflictError
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// errNoParent Returns the error of an entity whose parent, part of its endpoint, is unknown:
+// it was built instead of read from the device with getter
+func errNoParent(kind, id, parent, getter string) error {
+	return fmt.Errorf("%s %s has no %s, get it with %s", kind, id, parent, getter)
+}
+
+// parseResponse Returns the error described by the body of a failed request
+func parseResponse(method, endpoint string, statusCode int, bodyText []byte, authenticating bool) *APIError {
+	ae := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+	}
+
 	//spew.Dump(string(bodyText))
 	if len(bodyText) > 0 {
 		if !authenticating {
@@ -33,25 +131,26 @@ func parseResponse(bodyText []byte, authenticating bool) (err error) {
 
 			//log.Print("Response: " + string(bodyText))
 
-			err = json.Unmarshal(bodyText, &v)
-			if err != nil {
-				return err
+			err := json.Unmarshal(bodyText, &v)
+			if err == nil && v.Error != nil {
+				ae.FTDError = *v.Error
 			}
 
-			return v.Error
+			return ae
 		}
 
 		var v map[string]interface{}
 
 		//log.Print("Response: " + string(bodyText))
 
-		err = json.Unmarshal(bodyText, &v)
-		if err != nil {
-			return err
+		err := json.Unmarshal(bodyText, &v)
+		if err == nil {
+			if m, ok := v["message"].(string); ok {
+				ae.Key = "error getting token"
+				ae.Message = []FTDMessage{{Description: m}}
+			}
 		}
-
-		return fmt.Errorf("error getting token: %s", v["message"].(string))
-
 	}
-	return nil
+
+	return ae
 }
@@ -0,0 +1,114 @@
+package goftd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseResponse(t *testing.T) {
+	body := []byte(`{"error":{"severity":"ERROR","key":"Validation","messages":[{"description":"duplicate","code":"duplicateName","location":""}]}}`)
+
+	err := error(parseResponse(apiPOST, apiNetworksEndpoint, http.StatusUnprocessableEntity, body, false))
+
+	var ae *APIError
+	if !errors.As(err, &ae) {
+		t.Errorf("expecting an *APIError, got %T\n", err)
+		return
+	}
+
+	if ae.StatusCode != http.StatusUnprocessableEntity || ae.Method != apiPOST || ae.Endpoint != apiNetworksEndpoint {
+		t.Errorf("request is not populated correctly: %+v\n", ae)
+	}
+
+	if ae.Severity != "ERROR" || len(ae.Message) != 1 {
+		t.Errorf("FDM error is not populated correctly: %+v\n", ae)
+	}
+
+	if !IsDuplicate(err) || IsNotFound(err) || IsConflict(err) {
+		t.Errorf("expecting only a duplicate error: %s\n", err)
+	}
+
+	wrapped := fmt.Errorf("create: %w", err)
+	if !IsDuplicate(wrapped) {
+		t.Errorf("expecting a wrapped duplicate error: %s\n", wrapped)
+	}
+}
+
+func TestParseResponseStatus(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		body       string
+		sentinel   error
+	}{
+		{http.StatusNotFound, "", ErrNotFound},
+		{http.StatusUnauthorized, "not json", ErrUnauthorized},
+		{http.StatusConflict, `{"error":null}`, ErrConflict},
+		{http.StatusUnprocessableEntity, `{"error":{"messages":[{"code":"versionMismatch"}]}}`, ErrConflict},
+	}
+
+	for _, tt := range tests {
+		err := parseResponse(apiGET, apiNetworksEndpoint, tt.statusCode, []byte(tt.body), false)
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("%d: expecting %s, got %s\n", tt.statusCode, tt.sentinel, err)
+		}
+	}
+
+	err := parseResponse(apiPOST, apiTokenEndpoint, http.StatusBadRequest, []byte(`{"message":"Invalid credentials"}`), true)
+	if len(err.Message) != 1 || err.Message[0].Description != "Invalid credentials" {
+		t.Errorf("token error is not populated correctly: %+v\n", err)
+	}
+}
+
+func TestNoParent(t *testing.T) {
+	calls := 0
+	h := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"items":[]}`))
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+
+	// Built by the caller, e.g. from stored state, the parent in the endpoint isn't known
+	a := new(AccessRule)
+	a.ID = "rule1"
+	a.RuleAction = RuleActionPermit
+	o := new(ObjectNATRule)
+	o.ID = "rule2"
+	m := new(ManualNATRule)
+	m.ID = "rule3"
+	s := &SubInterface{VLANID: 10}
+	s.ID = "9d0b4c4b-2f0e-11e8-a6b4-e7b7c8a4a1d2"
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"UpdateAccessRule", func() error { return ftd.UpdateAccessRule(a) }},
+		{"MoveAccessRule", func() error { return ftd.MoveAccessRule(a, 0) }},
+		{"DeleteAccessRule", func() error { return ftd.DeleteAccessRule(a) }},
+		{"InsertAccessRuleBefore", func() error {
+			return ftd.InsertAccessRuleBefore(&AccessRule{RuleAction: RuleActionPermit}, a)
+		}},
+		{"UpdateObjectNATRule", func() error { return ftd.UpdateObjectNATRule(o) }},
+		{"DeleteObjectNATRule", func() error { return ftd.DeleteObjectNATRule(o) }},
+		{"MoveManualNATRule", func() error { return ftd.MoveManualNATRule(m, 0) }},
+		{"DeleteManualNATRule", func() error { return ftd.DeleteManualNATRule(m) }},
+		{"UpdateSubInterface", func() error { return ftd.UpdateSubInterface(s) }},
+		{"DeleteSubInterface", func() error { return ftd.DeleteSubInterface(s) }},
+	}
+
+	for _, tt := range tests {
+		err := tt.fn()
+		if err == nil || !strings.Contains(err.Error(), "has no") {
+			t.Errorf("%s: expecting an error without parent, got %v\n", tt.name, err)
+		}
+	}
+
+	if calls != 0 {
+		t.Errorf("expecting no request, got %d\n", calls)
+	}
+}
@@ -2,19 +2,20 @@ package goftd
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
-	"github.com/golang/glog"
-
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -23,8 +24,12 @@ type passwordGrant struct {
 	Password string
 }
 
-//TODO: implement this?
+// customGrant exchanges an existing access token for a custom token
 type customGrant struct {
+	AccessToken             string
+	DesiredSubject          string
+	DesiredExpiresIn        int
+	DesiredRefreshExpiresIn int
 }
 
 // FTD struct holding the FTD object
@@ -37,20 +42,35 @@ type FTD struct {
 	Insecure bool
 
 	// store access token and refresh token
-	accessToken  string
-	refreshToken string
-	expiresAt    time.Time
+	accessToken      string
+	refreshToken     string
+	expiresAt        time.Time
+	refreshExpiresAt time.Time
+	// serialize token refreshes
+	tokenMutex sync.Mutex
 
 	passwordGrant *passwordGrant
 	customGrant   *customGrant
 
-	debug bool
+	// HTTP settings
+	port        int
+	rootCAs     *x509.CertPool
+	fingerprint []byte
+	clientCerts []tls.Certificate
+	timeout     time.Duration
+	client      *http.Client
+	retryPolicy RetryPolicy
+	// wraps the transport of client, see WithTransport
+	wrapTransport func(http.RoundTripper) http.RoundTripper
+
+	logger Logger
+	debug  bool
 }
 
 type requestParameters struct {
 	// Request for POST / PUT
 	FTDRequest interface{}
-	// URI Query if needed (GET)
+	// URI Query if needed, e.g. filter for GET or at for POST / PUT
 	URIQuery map[string]string
 	// Paging parameters for GET
 	PageStart int
@@ -80,133 +100,222 @@ type Paging struct {
 	Pages  int      `json:"pages,omitempty"`
 }
 
-func (f *FTD) updateToken() error {
-	if f.GrantType == "" {
-		return fmt.Errorf("grant is not correctly initialized")
-	}
+// New returns an initialized FTD struct configured by opts, a grant option is mandatory
+func New(hostname string, opts ...Option) (*FTD, error) {
+	var err error
 
-	if f.GrantType == grantTypePassword && f.passwordGrant == nil {
-		return fmt.Errorf("grant is not correctly initialized")
-	} else if f.GrantType == grantTypeCustom && f.customGrant == nil {
-		return fmt.Errorf("grant is not correctly initialized")
+	if hostname == "" {
+		return nil, fmt.Errorf("hostname is mandatory")
 	}
 
-	req := make(map[string]string)
-	var res map[string]interface{}
-
-	if f.GrantType == grantTypePassword {
+	f := new(FTD)
+	f.Hostname = hostname
+	f.logger = glogLogger{}
+	f.retryPolicy = DefaultRetryPolicy()
 
-		req["grant_type"] = grantTypePassword
-		req["username"] = f.passwordGrant.Username
-		req["password"] = f.passwordGrant.Password
+	for _, opt := range opts {
+		err = opt(f)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return nil, err
+		}
 	}
 
-	data, err := f.Post(apiTokenEndpoint, req)
-	if err != nil {
-		return err
+	if f.GrantType == "" {
+		return nil, fmt.Errorf("grant is not correctly initialized")
 	}
 
-	err = json.Unmarshal(data, &res)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+	if f.client == nil {
+		f.client = f.newHTTPClient()
+	} else {
+		if f.Insecure || f.rootCAs != nil || f.fingerprint != nil || len(f.clientCerts) > 0 {
+			return nil, fmt.Errorf("TLS options can't be combined with a custom HTTP client")
 		}
-		return err
-	}
 
-	if _, ok := res["access_token"]; ok {
-		f.accessToken = res["access_token"].(string)
-	} else {
-		return fmt.Errorf("missing access_token in reply")
+		if f.timeout > 0 {
+			// Don't modify the caller's client
+			c := *f.client
+			c.Timeout = f.timeout
+			f.client = &c
+		}
 	}
 
-	if _, ok := res["refresh_token"]; ok {
-		f.refreshToken = res["refresh_token"].(string)
-	} else {
-		return fmt.Errorf("missing refresh_token in reply")
+	if f.wrapTransport != nil {
+		// Don't modify the caller's client
+		c := *f.client
+		if c.Transport == nil {
+			c.Transport = http.DefaultTransport
+		}
+		c.Transport = f.wrapTransport(c.Transport)
+		f.client = &c
 	}
 
-	if _, ok := res["expires_in"]; ok {
-		f.expiresAt = time.Now().Add(time.Second * time.Duration(res["expires_in"].(float64)))
-	} else {
-		return fmt.Errorf("missing expires_in in reply")
+	err = f.updateToken(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return f, nil
 }
 
 // NewFTD returns an initilized FTD struct
 func NewFTD(hostname string, param map[string]string) (*FTD, error) {
-	f := new(FTD)
-	f.Hostname = hostname
-	f.debug = false
-	f.Insecure = false
+	var opts []Option
 
-	if _, ok := param["debug"]; ok {
-		if param["debug"] == "true" {
-			f.debug = true
-		}
+	if param["debug"] == "true" {
+		opts = append(opts, WithDebug())
 	}
 
-	if _, ok := param["insecure"]; ok {
-		if param["insecure"] == "true" {
-			f.Insecure = true
-		}
+	if param["insecure"] == "true" {
+		opts = append(opts, WithInsecureTLS())
+	}
+
+	if _, ok := param["ca_cert"]; ok {
+		opts = append(opts, WithCACertFile(param["ca_cert"]))
+	}
+
+	if _, ok := param["fingerprint"]; ok {
+		opts = append(opts, WithCertificateFingerprint(param["fingerprint"]))
 	}
 
 	if _, ok := param["grant_type"]; ok {
-		if param["grant_type"] == grantTypePassword || param["grant_type"] == grantTypeCustom {
-			f.GrantType = param["grant_type"]
-			if param["grant_type"] == grantTypePassword {
-				f.passwordGrant = new(passwordGrant)
-				if _, ok := param["username"]; ok {
-					f.passwordGrant.Username = param["username"]
-				} else {
-					if f.debug {
-						glog.Errorf("username is mandatory for grant type = %s\n", grantTypePassword)
-					}
-					return nil, fmt.Errorf("username is mandatory for grant type = %s", grantTypePassword)
+		switch param["grant_type"] {
+		case grantTypePassword:
+			for _, k := range []string{"username", "password"} {
+				if _, ok := param[k]; !ok {
+					return nil, fmt.Errorf("%s is mandatory for grant type = %s", k, grantTypePassword)
 				}
+			}
+
+			opts = append(opts, WithPasswordGrant(param["username"], param["password"]))
+		case grantTypeCustom:
+			if _, ok := param["access_token"]; !ok {
+				return nil, fmt.Errorf("access_token is mandatory for grant type = %s", grantTypeCustom)
+			}
 
-				if _, ok := param["password"]; ok {
-					f.passwordGrant.Password = param["password"]
-				} else {
-					if f.debug {
-						glog.Errorf("password is mandatory for grant type = %s\n", grantTypePassword)
+			var lifetimes [2]time.Duration
+			for i, k := range []string{"desired_expires_in", "desired_refresh_expires_in"} {
+				if _, ok := param[k]; ok {
+					v, err := strconv.Atoi(param[k])
+					if err != nil {
+						return nil, fmt.Errorf("invalid %s: %s", k, param[k])
 					}
-					return nil, fmt.Errorf("password is mandatory for grant type = %s", grantTypePassword)
+					lifetimes[i] = time.Duration(v) * time.Second
 				}
 			}
-		} else {
-			if f.debug {
-				glog.Errorf("unknown grant type: %s\n", param["grant_type"])
-			}
+
+			opts = append(opts, WithCustomGrant(param["access_token"], param["desired_subject"], lifetimes[0], lifetimes[1]))
+		default:
 			return nil, fmt.Errorf("unknown grant type: %s", param["grant_type"])
 		}
 	}
 
-	err := f.updateToken()
+	return New(hostname, opts...)
+}
+
+// newHTTPClient Returns the client shared by every request of the session
+func (f *FTD) newHTTPClient() *http.Client {
+	tr := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     f.newTLSConfig(),
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &http.Client{
+		Transport: tr,
+		Timeout:   f.timeout,
+	}
+}
+
+// host Returns the host and port requests are sent to
+func (f *FTD) host() string {
+	if f.port > 0 {
+		return net.JoinHostPort(f.Hostname, strconv.Itoa(f.port))
+	}
+
+	return f.Hostname
+}
+
+func (f *FTD) request(ctx context.Context, endpoint, method string, r *requestParameters) (bodyText []byte, err error) {
+	var resp *http.Response
+
+	for attempt := 1; ; attempt++ {
+		bodyText, resp, err = f.attempt(ctx, endpoint, method, r)
+		if err == nil || ctx.Err() != nil || !f.retryPolicy.retryable(method, attempt, resp, err) {
+			if err != nil && method == apiPUT && r != nil {
+				err = versionConflict(r.FTDRequest, err)
+			}
+			return bodyText, err
+		}
+
+		e := RetryEvent{
+			Method:   method,
+			Endpoint: endpoint,
+			Attempt:  attempt,
+			Err:      err,
+			Wait:     f.retryPolicy.backoff(attempt, resp),
+		}
+		if resp != nil {
+			e.StatusCode = resp.StatusCode
+		}
+
+		if f.debug {
+			f.logger.Warningf("%s %s failed (attempt %d), retrying in %s: %s\n", method, endpoint, attempt, e.Wait, err)
+		}
+		if f.retryPolicy.OnRetry != nil {
+			f.retryPolicy.OnRetry(e)
+		}
+
+		t := time.NewTimer(e.Wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// attempt Sends a request with a valid token, the request is sent again once with a fresh token on 401
+func (f *FTD) attempt(ctx context.Context, endpoint, method string, r *requestParameters) (bodyText []byte, resp *http.Response, err error) {
+	if endpoint == apiTokenEndpoint && method == apiPOST {
+		return f.do(ctx, endpoint, method, r, "")
+	}
+
+	token, err := f.token(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return f, nil
+	bodyText, resp, err = f.do(ctx, endpoint, method, r, token)
+	if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+		// The token was rejected, retry once with a fresh one
+		token, err = f.renewToken(ctx, token)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bodyText, resp, err = f.do(ctx, endpoint, method, r, token)
+	}
+
+	return bodyText, resp, err
 }
 
-func (f *FTD) request(endpoint, method string, r *requestParameters) (bodyText []byte, err error) {
-	var authenticating bool
+// do Sends a single request, token is empty when authenticating. resp is nil when no response was received.
+func (f *FTD) do(ctx context.Context, endpoint, method string, r *requestParameters, token string) (bodyText []byte, resp *http.Response, err error) {
 	var req *http.Request
 	var jsonReq []byte
 	var body io.Reader
 
-	if endpoint == apiTokenEndpoint && method == "POST" {
-		authenticating = true
-	} else {
-		authenticating = false
-	}
+	authenticating := token == ""
 
 	uri := url.URL{
-		Host:   f.Hostname,
+		Host:   f.host(),
 		Scheme: "https",
 		Path:   apiBasePath + endpoint,
 	}
@@ -216,115 +325,125 @@ func (f *FTD) request(endpoint, method string, r *requestParameters) (bodyText [
 		if r != nil && r.FTDRequest != nil {
 			jsonReq, err = json.Marshal(r.FTDRequest)
 			if err != nil {
-				glog.Errorf("request - marshall error: %s\n", err)
-				return nil, err
+				f.logger.Errorf("request - marshall error: %s\n", err)
+				return nil, nil, err
 			}
 			body = bytes.NewBuffer(jsonReq)
 		} else {
 			body = nil
 		}
 
-		req, err = http.NewRequest(method, uri.String(), body)
+		req, err = http.NewRequestWithContext(ctx, method, uri.String(), body)
 		if err != nil {
-			glog.Errorln(err)
-			return nil, err
+			f.logger.Errorf("%s\n", err)
+			return nil, nil, err
 		}
 		req.Header.Set("Content-Type", "application/json")
 
 	case apiGET, apiDELETE:
-		req, err = http.NewRequest(method, uri.String(), nil)
+		req, err = http.NewRequestWithContext(ctx, method, uri.String(), nil)
 		if err != nil {
-			log.Print(err)
-			return nil, err
+			f.logger.Errorf("%s\n", err)
+			return nil, nil, err
 		}
 		req.Header.Set("Content-Type", "application/json")
 
-		q := req.URL.Query()
-		if r != nil {
-			for k, v := range r.URIQuery {
-				q.Add(k, v)
+		if method == apiGET && r != nil {
+			q := req.URL.Query()
+			if r.PageLimit > 0 {
+				q.Set("limit", strconv.Itoa(r.PageLimit))
 			}
-		}
-		// if method == apiGET {
-		// 	if r != nil && r.PageLimit > 0 {
-		// 		q.Add("limit", string(r.PageLimit))
-		// 	}
 
-		// 	if r != nil && r.PageStart > 0 {
-		// 		q.Add("start", string(r.PageStart))
-		// 	}
-		// }
-
-		req.URL.RawQuery = q.Encode()
+			if r.PageStart > 0 {
+				q.Set("offset", strconv.Itoa(r.PageStart))
+			}
+			req.URL.RawQuery = q.Encode()
+		}
 
 	default:
-		return nil, fmt.Errorf("Unknown Method %s", method)
+		return nil, nil, fmt.Errorf("Unknown Method %s", method)
 	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	if r != nil && len(r.URIQuery) > 0 {
+		q := req.URL.Query()
+		for k, v := range r.URIQuery {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
 	}
-	client := &http.Client{Transport: tr}
 
 	if !authenticating {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.accessToken))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	}
 
-	resp, err := client.Do(req)
+	resp, err = f.client.Do(req)
 	if err != nil {
-		glog.Errorln(err)
-		return nil, err
+		f.logger.Errorf("%s\n", err)
+		return nil, nil, err
 	}
+	defer resp.Body.Close()
 
 	bodyText, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		glog.Errorf("request - readall error: %s\n", err)
+		f.logger.Errorf("request - readall error: %s\n", err)
 		spew.Dump(resp)
-		return nil, err
+		return nil, resp, err
 	}
 
-	glog.Infof("Response: %s\n", strconv.Itoa(resp.StatusCode))
+	f.logger.Infof("Response: %s\n", strconv.Itoa(resp.StatusCode))
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		err = parseResponse(bodyText, authenticating)
-		if err != nil {
-			// if f.debug {
-			// 	glog.Errorf("POST - parse response error: %s\n", err)
-			// }
-			return nil, err
-		}
-
-		return nil, fmt.Errorf("response code: %d", resp.StatusCode)
+		return nil, resp, parseResponse(method, endpoint, resp.StatusCode, bodyText, authenticating)
 	}
 
-	return bodyText, nil
+	return bodyText, resp, nil
 }
 
 // Post POST to ASA API
 func (f *FTD) Post(endpoint string, ftdReq interface{}) (bodyText []byte, err error) {
+	return f.PostContext(context.Background(), endpoint, ftdReq)
+}
+
+// PostContext Same as Post, ctx cancels the requests
+func (f *FTD) PostContext(ctx context.Context, endpoint string, ftdReq interface{}) (bodyText []byte, err error) {
 	r := requestParameters{
 		FTDRequest: ftdReq,
 	}
-	return f.request(endpoint, apiPOST, &r)
+	return f.request(ctx, endpoint, apiPOST, &r)
 }
 
 // Put PUT to ASA API
 func (f *FTD) Put(endpoint string, ftdReq interface{}) (bodyText []byte, err error) {
+	return f.PutContext(context.Background(), endpoint, ftdReq)
+}
+
+// PutContext Same as Put, ctx cancels the requests
+func (f *FTD) PutContext(ctx context.Context, endpoint string, ftdReq interface{}) (bodyText []byte, err error) {
 	r := requestParameters{
 		FTDRequest: ftdReq,
 	}
-	return f.request(endpoint, apiPUT, &r)
+	return f.request(ctx, endpoint, apiPUT, &r)
 }
 
 // Get GET to ASA API
 func (f *FTD) Get(endpoint string, uriQuery map[string]string) (bodyText []byte, err error) {
+	return f.GetContext(context.Background(), endpoint, uriQuery)
+}
+
+// GetContext Same as Get, ctx cancels the requests
+func (f *FTD) GetContext(ctx context.Context, endpoint string, uriQuery map[string]string) (bodyText []byte, err error) {
 	r := requestParameters{
 		URIQuery: uriQuery,
 	}
-	return f.request(endpoint, apiGET, &r)
+	return f.request(ctx, endpoint, apiGET, &r)
 }
 
 // Delete DELETE to ASA API
 func (f *FTD) Delete(endpoint string) (err error) {
-	_, err = f.request(endpoint, apiDELETE, nil)
+	return f.DeleteContext(context.Background(), endpoint)
+}
+
+// DeleteContext Same as Delete, ctx cancels the requests
+func (f *FTD) DeleteContext(ctx context.Context, endpoint string) (err error) {
+	_, err = f.request(ctx, endpoint, apiDELETE, nil)
 	return err
 }
@@ -1,13 +1,28 @@
 package goftd
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/glog"
+	"github.com/remiphilippe/go-ftd/ftdtest"
 )
 
+var (
+	fakeOnce sync.Once
+	fake     *ftdtest.Server
+)
+
+// initTest Returns a session to the device in FTD_HOST, or to a fake FDM shared by the tests when it isn't set
 func initTest() (*FTD, error) {
+	host := os.Getenv("FTD_HOST")
+
 	params := make(map[string]string)
 	params["grant_type"] = "password"
 	params["username"] = os.Getenv("FTD_USER")
@@ -15,7 +30,17 @@ func initTest() (*FTD, error) {
 	params["debug"] = "true"
 	params["insecure"] = "true"
 
-	ftd, err := NewFTD(os.Getenv("FTD_HOST"), params)
+	if host == "" {
+		fakeOnce.Do(func() {
+			fake = ftdtest.NewServer()
+		})
+
+		host = fake.Host()
+		params["username"] = ftdtest.Username
+		params["password"] = ftdtest.Password
+	}
+
+	ftd, err := NewFTD(host, params)
 	if err != nil {
 		glog.Errorf("error: %s\n", err)
 		return nil, err
@@ -24,6 +49,24 @@ func initTest() (*FTD, error) {
 	return ftd, nil
 }
 
+// newStubFTD Returns a session to a TLS server answering with h, retries are disabled. Call done to stop the server.
+func newStubFTD(t *testing.T, h http.HandlerFunc) (ftd *FTD, done func()) {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(h)
+
+	ftd = &FTD{
+		Hostname:    strings.TrimPrefix(srv.URL, "https://"),
+		accessToken: "token",
+		expiresAt:   time.Now().Add(time.Hour),
+		client:      srv.Client(),
+		logger:      glogLogger{},
+		retryPolicy: RetryPolicy{MaxAttempts: 1},
+	}
+
+	return ftd, srv.Close
+}
+
 func TestToken(t *testing.T) {
 	ftd, err := initTest()
 	if err != nil {
@@ -36,3 +79,21 @@ func TestToken(t *testing.T) {
 	}
 	//spew.Dump(ftd)
 }
+
+func TestContextCancel(t *testing.T) {
+	ftd := &FTD{
+		Hostname:    "127.0.0.1:1",
+		accessToken: "token",
+		expiresAt:   time.Now().Add(time.Hour),
+		client:      http.DefaultClient,
+		logger:      glogLogger{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ftd.GetNetworkObjectsContext(ctx, 0)
+	if err != context.Canceled {
+		t.Errorf("expecting %s, got %v\n", context.Canceled, err)
+	}
+}
@@ -0,0 +1,334 @@
+package ftdtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// redacted replaces the secrets of the recorded interactions
+const redacted string = "REDACTED"
+
+// recordedHost replaces the address of the device in the recorded bodies, e.g. in the links
+const recordedHost string = "ftd.example.com"
+
+// secretKeyParts the values of the JSON keys containing one of these, whatever the case, are scrubbed
+// from the recorded bodies, e.g. password, authPassword, preSharedKey or access_token
+var secretKeyParts = []string{"password", "secret", "token", "key"}
+
+// publicKeys keys matching secretKeyParts that never hold a secret, e.g. the key of the FDM errors
+var publicKeys = map[string]bool{
+	"key":        true,
+	"token_type": true,
+}
+
+// RecordedRequest A request as stored in a cassette, the Authorization header is never stored
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse A response as stored in a cassette
+type RecordedResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// Interaction A request and the response of the device
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette The interactions of a session, in the order they happened
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// LoadCassette Reads a cassette saved by Recorder.Save
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *Cassette
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %s", path, err)
+	}
+
+	return c, nil
+}
+
+// Save Writes the cassette to path as indented JSON
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Recorder Records the interactions going through a transport, with tokens, passwords, secrets and keys scrubbed.
+//
+//	rec := ftdtest.NewRecorder()
+//	ftd, err := goftd.New(host, goftd.WithPasswordGrant(username, password), goftd.WithTransport(rec.Wrap))
+//	...
+//	err = rec.Save("testdata/networks.json")
+type Recorder struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder Returns an empty recorder, see Wrap
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Wrap Sends the requests to next and records them, to give to goftd.WithTransport
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	r.next = next
+
+	return r
+}
+
+// RoundTrip Sends req and records the interaction
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.next == nil {
+		return nil, fmt.Errorf("ftdtest: recorder is not wrapping a transport")
+	}
+
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+
+		reqBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	i := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  normalizeQuery(req.URL.RawQuery),
+			Body:   scrub(reqBody, req.URL.Host),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Body:       scrub(respBody, req.URL.Host),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette Returns the interactions recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := Cassette{
+		Interactions: append([]*Interaction(nil), r.cassette.Interactions...),
+	}
+
+	return &c
+}
+
+// Save Writes the interactions recorded so far to path
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer Answers the requests with the responses of a cassette, without any network access.
+// Each interaction is replayed once, in order, for the request with the same method, path and query.
+//
+//	rp, err := ftdtest.NewReplayerFromFile("testdata/networks.json")
+//	ftd, err := goftd.New("ftd.example.com", goftd.WithPasswordGrant("admin", "password"), goftd.WithTransport(rp.Wrap))
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer Returns a replayer of c
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		cassette: c,
+		used:     make([]bool, len(c.Interactions)),
+	}
+}
+
+// NewReplayerFromFile Returns a replayer of the cassette saved in path
+func NewReplayerFromFile(path string) (*Replayer, error) {
+	c, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayer(c), nil
+}
+
+// Wrap Replaces next, to give to goftd.WithTransport
+func (p *Replayer) Wrap(next http.RoundTripper) http.RoundTripper {
+	return p
+}
+
+// RoundTrip Returns the recorded response of the first interaction not replayed yet matching req
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	query := normalizeQuery(req.URL.RawQuery)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for n, i := range p.cassette.Interactions {
+		if p.used[n] || i.Request.Method != req.Method || i.Request.Path != req.URL.Path || i.Request.Query != query {
+			continue
+		}
+		p.used[n] = true
+
+		body := []byte(i.Response.Body)
+		var text string
+		if json.Unmarshal(body, &text) == nil {
+			// Recorded as a string because it wasn't JSON
+			body = []byte(text)
+		}
+
+		resp := &http.Response{
+			StatusCode:    i.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        make(http.Header),
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}
+		resp.Header.Set("Content-Type", "application/json")
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("ftdtest: no recorded interaction left for %s %s?%s", req.Method, req.URL.Path, query)
+}
+
+// Remaining Returns the number of interactions not replayed yet
+func (p *Replayer) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+	for _, used := range p.used {
+		if !used {
+			count++
+		}
+	}
+
+	return count
+}
+
+// normalizeQuery Returns the query with its parameters sorted, "" when there is none
+func normalizeQuery(raw string) string {
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+
+	return q.Encode()
+}
+
+// scrub Returns body with the secrets redacted and host replaced, as JSON
+func scrub(body []byte, host string) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	if host != "" {
+		body = bytes.Replace(body, []byte(host), []byte(recordedHost), -1)
+	}
+
+	var v interface{}
+	err := json.Unmarshal(body, &v)
+	if err != nil {
+		// Not JSON, keep it as a string
+		data, _ := json.Marshal(string(body))
+		return data
+	}
+
+	data, _ := json.Marshal(redact(v))
+
+	return data
+}
+
+// redact Replaces the values of the secret keys in v
+func redact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if isSecret(k) {
+				if s, ok := e.(string); ok && s != "" {
+					t[k] = redacted
+				}
+				continue
+			}
+			t[k] = redact(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redact(e)
+		}
+	}
+
+	return v
+}
+
+// isSecret Returns true if the value of the JSON key k must be scrubbed
+func isSecret(k string) bool {
+	k = strings.ToLower(k)
+	if publicKeys[k] {
+		return false
+	}
+
+	for _, part := range secretKeyParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package ftdtest
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestScrub(t *testing.T) {
+	body := []byte(`{"grant_type":"password","username":"admin","password":"Admin123","items":[{"access_token":"abc","links":{"self":"https://10.0.0.1:8443/api/fdm/v1/object/networks/1"}},` +
+		`{"authPassword":"auth123","privPassword":"priv123","secret":"s3cret","sharedKey":"shared123","preSharedKey":"psk123"}],` +
+		`"error":{"key":"Validation"}}`)
+
+	data := scrub(body, "10.0.0.1:8443")
+	for _, secret := range []string{"Admin123", "abc", "10.0.0.1", "auth123", "priv123", "s3cret", "shared123", "psk123"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("scrubbed body contains %s: %s\n", secret, data)
+		}
+	}
+
+	if !bytes.Contains(data, []byte(`"username":"admin"`)) {
+		t.Errorf("expecting the username to be kept: %s\n", data)
+	}
+
+	if !bytes.Contains(data, []byte(`"key":"Validation"`)) {
+		t.Errorf("expecting the key of the error to be kept: %s\n", data)
+	}
+
+	if s := scrub([]byte("not json"), ""); string(s) != `"not json"` {
+		t.Errorf("expecting a JSON string, got %s\n", s)
+	}
+}
+
+func TestReplayerOrder(t *testing.T) {
+	c := &Cassette{
+		Interactions: []*Interaction{
+			{Request: RecordedRequest{Method: "GET", Path: "/a"}, Response: RecordedResponse{StatusCode: 200, Body: []byte(`{"n":1}`)}},
+			{Request: RecordedRequest{Method: "GET", Path: "/a"}, Response: RecordedResponse{StatusCode: 404}},
+		},
+	}
+
+	p := NewReplayer(c)
+	for _, status := range []int{200, 404} {
+		req, _ := http.NewRequest("GET", "https://ftd.example.com/a", nil)
+		resp, err := p.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+
+		if resp.StatusCode != status {
+			t.Errorf("expecting status %d, got %d\n", status, resp.StatusCode)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "https://ftd.example.com/a", nil)
+	_, err := p.RoundTrip(req)
+	if err == nil {
+		t.Errorf("expecting an error once the cassette is exhausted\n")
+	}
+}
@@ -0,0 +1,482 @@
+package ftdtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultPageLimit number of items returned when the request has no limit, as FDM does
+const defaultPageLimit int = 10
+
+// itemDefaults values FDM gives to the fields a request leaves out, by item type
+var itemDefaults = map[string]map[string]interface{}{
+	"accessrule": {
+		"eventLogAction": "LOG_NONE",
+	},
+}
+
+// child A collection nested under every item of a collection, e.g. the rules of a policy
+type child struct {
+	name     string
+	itemType string
+}
+
+// collection An ordered list of items sharing an endpoint
+type collection struct {
+	endpoint string
+	itemType string
+	// items can be updated but not created or deleted, e.g. physical interfaces
+	fixed    bool
+	children []child
+	items    []map[string]interface{}
+}
+
+// index Returns the position of the item with ID id, or -1
+func (c *collection) index(id string) int {
+	for i, item := range c.items {
+		if item["id"] == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// byName Returns the item named name, or nil
+func (c *collection) byName(name string) map[string]interface{} {
+	for _, item := range c.items {
+		if item["name"] == name {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// insert Adds item at position at, a negative or out of range position appends it
+func (c *collection) insert(item map[string]interface{}, at int) {
+	if at < 0 || at > len(c.items) {
+		at = len(c.items)
+	}
+
+	c.items = append(c.items, nil)
+	copy(c.items[at+1:], c.items[at:])
+	c.items[at] = item
+}
+
+// remove Removes the item at position i and returns it
+func (c *collection) remove(i int) map[string]interface{} {
+	item := c.items[i]
+	c.items = append(c.items[:i], c.items[i+1:]...)
+
+	return item
+}
+
+// copy Returns a deep copy of the collection
+func (c *collection) copy() *collection {
+	n := *c
+	n.items = make([]map[string]interface{}, len(c.items))
+	for i, item := range c.items {
+		n.items[i] = copyItem(item)
+	}
+
+	return &n
+}
+
+// addCollection Registers an empty collection, returns the existing one if any
+func (s *Server) addCollection(endpoint, itemType string, children ...child) *collection {
+	c, ok := s.collections[endpoint]
+	if !ok {
+		c = &collection{
+			endpoint: endpoint,
+			itemType: itemType,
+		}
+		s.collections[endpoint] = c
+	}
+	c.children = append(c.children, children...)
+
+	return c
+}
+
+// newItem Assigns an ID and a version to item and creates its nested collections
+func (s *Server) newItem(c *collection, item map[string]interface{}) map[string]interface{} {
+	if id, _ := item["id"].(string); id == "" {
+		item["id"] = newID()
+	}
+	if t, _ := item["type"].(string); t == "" {
+		item["type"] = c.itemType
+	}
+	item["version"] = newVersion()
+	delete(item, "links")
+
+	for _, ch := range c.children {
+		s.addCollection(fmt.Sprintf("%s/%s/%s", c.endpoint, item["id"], ch.name), ch.itemType)
+	}
+
+	return item
+}
+
+// serveCollection Dispatches a request on a collection or on one of its items
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, endpoint string, body map[string]interface{}) {
+	if c, ok := s.collections[endpoint]; ok {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, c)
+		case http.MethodPost:
+			s.create(w, r, c, body)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "", fmt.Sprintf("%s not allowed on %s", r.Method, endpoint))
+		}
+		return
+	}
+
+	i := strings.LastIndex(endpoint, "/")
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NotFound", "", fmt.Sprintf("unknown endpoint %s", endpoint))
+		return
+	}
+
+	c, ok := s.collections[endpoint[:i]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NotFound", "", fmt.Sprintf("unknown endpoint %s", endpoint))
+		return
+	}
+
+	id := endpoint[i+1:]
+	if c.index(id) < 0 {
+		writeError(w, http.StatusNotFound, "NotFound", "", fmt.Sprintf("object %s not found in %s", id, c.endpoint))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.render(c, c.items[c.index(id)]))
+	case http.MethodPut:
+		s.update(w, r, c, id, body)
+	case http.MethodDelete:
+		s.delete(w, c, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "", fmt.Sprintf("%s not allowed on %s", r.Method, endpoint))
+	}
+}
+
+// list Writes a page of the items matching the filter of the request
+func (s *Server) list(w http.ResponseWriter, r *http.Request, c *collection) {
+	q := r.URL.Query()
+
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+
+	var matches []map[string]interface{}
+	for _, item := range c.items {
+		if match(item, q.Get("filter")) {
+			matches = append(matches, item)
+		}
+	}
+
+	items := make([]map[string]interface{}, 0, limit)
+	for i := offset; i < len(matches) && i < offset+limit; i++ {
+		items = append(items, s.render(c, matches[i]))
+	}
+
+	paging := map[string]interface{}{
+		"prev":   []string{},
+		"next":   []string{},
+		"limit":  limit,
+		"offset": offset,
+		"count":  len(matches),
+		"pages":  int(math.Ceil(float64(len(matches)) / float64(limit))),
+	}
+
+	if offset > 0 {
+		paging["prev"] = []string{s.pageLink(c, q, offset-limit, limit)}
+	}
+
+	if offset+limit < len(matches) {
+		paging["next"] = []string{s.pageLink(c, q, offset+limit, limit)}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  items,
+		"paging": paging,
+	})
+}
+
+// pageLink Returns the link to the page starting at offset, keeping the filter of q
+func (s *Server) pageLink(c *collection, q url.Values, offset, limit int) string {
+	if offset < 0 {
+		offset = 0
+	}
+
+	v := url.Values{}
+	for k := range q {
+		v.Set(k, q.Get(k))
+	}
+	v.Set("offset", strconv.Itoa(offset))
+	v.Set("limit", strconv.Itoa(limit))
+
+	return fmt.Sprintf("%s%s%s?%s", s.URL, basePath, c.endpoint, v.Encode())
+}
+
+// withDefaults Sets the fields of item left out of the request to their default value and returns item
+func withDefaults(c *collection, item map[string]interface{}) map[string]interface{} {
+	for k, v := range itemDefaults[c.itemType] {
+		if _, ok := item[k]; !ok {
+			item[k] = v
+		}
+	}
+
+	return item
+}
+
+// match Returns true if item matches filter, e.g. name:foo matches every name containing foo
+func match(item map[string]interface{}, filter string) bool {
+	if filter == "" {
+		return true
+	}
+
+	for _, f := range strings.Split(filter, ";") {
+		kv := strings.SplitN(f, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		v := fmt.Sprint(item[kv[0]])
+		if kv[0] == "name" {
+			if !strings.Contains(v, kv[1]) {
+				return false
+			}
+		} else if v != kv[1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// create Adds an item to the collection, at the position given by the at parameter if any
+func (s *Server) create(w http.ResponseWriter, r *http.Request, c *collection, body map[string]interface{}) {
+	if c.fixed {
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "", fmt.Sprintf("objects of %s can't be created", c.endpoint))
+		return
+	}
+
+	if !s.validate(w, c, body, "") {
+		return
+	}
+
+	if id, _ := body["id"].(string); id != "" && c.index(id) >= 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "newInstanceWithDuplicateId", fmt.Sprintf("an object with ID %s already exists", id))
+		return
+	}
+
+	at, ok := position(w, r)
+	if !ok {
+		return
+	}
+
+	item := s.newItem(c, withDefaults(c, body))
+	c.insert(item, at)
+
+	writeJSON(w, http.StatusOK, s.render(c, item))
+}
+
+// update Replaces an item, its version must match the current one
+func (s *Server) update(w http.ResponseWriter, r *http.Request, c *collection, id string, body map[string]interface{}) {
+	i := c.index(id)
+	old := c.items[i]
+
+	if old["isSystemDefined"] == true {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "systemDefinedObject", fmt.Sprintf("system defined object %s can't be modified", old["name"]))
+		return
+	}
+
+	if body["version"] != old["version"] {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "invalidVersion", fmt.Sprintf("version %v of %s is not the current version %v", body["version"], id, old["version"]))
+		return
+	}
+
+	if !s.validate(w, c, body, id) {
+		return
+	}
+
+	at, ok := position(w, r)
+	if !ok {
+		return
+	}
+
+	body["id"] = id
+	if t, _ := body["type"].(string); t == "" {
+		body["type"] = old["type"]
+	}
+	body["version"] = newVersion()
+	delete(body, "links")
+	withDefaults(c, body)
+
+	if at < 0 {
+		c.items[i] = body
+	} else {
+		c.remove(i)
+		c.insert(body, at)
+	}
+
+	writeJSON(w, http.StatusOK, s.render(c, body))
+}
+
+// delete Removes an item, unless it is system defined or referenced by another item
+func (s *Server) delete(w http.ResponseWriter, c *collection, id string) {
+	i := c.index(id)
+	item := c.items[i]
+
+	if c.fixed || item["isSystemDefined"] == true {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "systemDefinedObject", fmt.Sprintf("system defined object %s can't be deleted", item["name"]))
+		return
+	}
+
+	if by := s.referencedBy(c, id); by != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "deleteObjectInUse", fmt.Sprintf("%s is referenced by %s %s", item["name"], by["type"], by["name"]))
+		return
+	}
+
+	c.remove(i)
+
+	prefix := fmt.Sprintf("%s/%s/", c.endpoint, id)
+	for endpoint := range s.collections {
+		if strings.HasPrefix(endpoint, prefix) {
+			delete(s.collections, endpoint)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validate Checks the name of item, exclude is the ID of the item being updated
+func (s *Server) validate(w http.ResponseWriter, c *collection, item map[string]interface{}, exclude string) bool {
+	name, _ := item["name"].(string)
+	if name == "" && !c.fixed {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "fieldRequired", "name is mandatory")
+		return false
+	}
+
+	if o := c.byName(name); name != "" && o != nil && o["id"] != exclude {
+		writeError(w, http.StatusUnprocessableEntity, "Validation", "duplicateName", fmt.Sprintf("an object named %s already exists", name))
+		return false
+	}
+
+	return true
+}
+
+// position Returns the value of the at parameter, or -1 when there is none
+func position(w http.ResponseWriter, r *http.Request) (int, bool) {
+	v := r.URL.Query().Get("at")
+	if v == "" {
+		return -1, true
+	}
+
+	at, err := strconv.Atoi(v)
+	if err != nil || at < 0 {
+		writeError(w, http.StatusBadRequest, "BadRequest", "invalidParameter", fmt.Sprintf("invalid position %s", v))
+		return 0, false
+	}
+
+	return at, true
+}
+
+// referencedBy Returns the first item, outside of the item itself and its nested collections, referencing id
+func (s *Server) referencedBy(c *collection, id string) map[string]interface{} {
+	prefix := fmt.Sprintf("%s/%s/", c.endpoint, id)
+
+	for _, endpoint := range endpoints(s.collections) {
+		if strings.HasPrefix(endpoint, prefix) {
+			continue
+		}
+
+		for _, item := range s.collections[endpoint].items {
+			if item["id"] == id {
+				continue
+			}
+
+			for _, v := range item {
+				if references(v, id) {
+					return item
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// references Returns true if v is or contains a reference to id
+func references(v interface{}, id string) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if t["id"] == id {
+			return true
+		}
+		for _, e := range t {
+			if references(e, id) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, e := range t {
+			if references(e, id) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// render Returns a copy of item with its self link
+func (s *Server) render(c *collection, item map[string]interface{}) map[string]interface{} {
+	r := copyItem(item)
+	if id, ok := item["id"].(string); ok {
+		r["links"] = map[string]interface{}{
+			"self": fmt.Sprintf("%s%s%s/%s", s.URL, basePath, c.endpoint, id),
+		}
+	}
+
+	return r
+}
+
+// endpoints Returns the endpoints of collections, sorted
+func endpoints(collections map[string]*collection) []string {
+	retval := make([]string, 0, len(collections))
+	for endpoint := range collections {
+		retval = append(retval, endpoint)
+	}
+	sort.Strings(retval)
+
+	return retval
+}
+
+// newVersion Returns a random version
+func newVersion() string {
+	return newToken()[:13]
+}
+
+// copyItem Returns a deep copy of item
+func copyItem(item map[string]interface{}) map[string]interface{} {
+	var r map[string]interface{}
+
+	data, _ := json.Marshal(item)
+	json.Unmarshal(data, &r)
+
+	return r
+}
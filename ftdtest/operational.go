@@ -0,0 +1,141 @@
+package ftdtest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// snapshot Returns a deep copy of the configuration
+func (s *Server) snapshot() map[string]*collection {
+	retval := make(map[string]*collection, len(s.collections))
+	for endpoint, c := range s.collections {
+		retval[endpoint] = c.copy()
+	}
+
+	return retval
+}
+
+// pendingChanges Returns the differences between the deployed configuration and the current one
+func (s *Server) pendingChanges() []map[string]interface{} {
+	var retval []map[string]interface{}
+
+	deployed := make(map[string]map[string]interface{})
+	for _, c := range s.deployed {
+		for _, item := range c.items {
+			deployed[item["id"].(string)] = item
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, endpoint := range endpoints(s.collections) {
+		for _, item := range s.collections[endpoint].items {
+			id := item["id"].(string)
+			current[id] = true
+
+			before, ok := deployed[id]
+			if !ok {
+				retval = append(retval, change("ADD", nil, item))
+			} else if !reflect.DeepEqual(before, item) {
+				retval = append(retval, change("EDIT", before, item))
+			}
+		}
+	}
+
+	for _, endpoint := range endpoints(s.deployed) {
+		for _, item := range s.deployed[endpoint].items {
+			if !current[item["id"].(string)] {
+				retval = append(retval, change("DELETE", item, nil))
+			}
+		}
+	}
+
+	return retval
+}
+
+// change Returns a pending change of an entity
+func change(changeType string, before, after map[string]interface{}) map[string]interface{} {
+	entity := after
+	if entity == nil {
+		entity = before
+	}
+
+	retval := map[string]interface{}{
+		"entityId":   entity["id"],
+		"entityName": entity["name"],
+		"entityType": entity["type"],
+		"changeType": changeType,
+		"type":       "configchangeentity",
+	}
+
+	if before != nil {
+		retval["entityBefore"] = before
+	}
+
+	if after != nil {
+		retval["entityAfter"] = after
+	}
+
+	return retval
+}
+
+// servePendingChanges Lists the pending changes, DELETE discards them
+func (s *Server) servePendingChanges(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c := &collection{
+			endpoint: pendingChangesEndpoint,
+			items:    s.pendingChanges(),
+		}
+		s.list(w, r, c)
+	case http.MethodDelete:
+		s.collections = make(map[string]*collection, len(s.deployed))
+		for endpoint, c := range s.deployed {
+			s.collections[endpoint] = c.copy()
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "", fmt.Sprintf("%s not allowed on %s", r.Method, pendingChangesEndpoint))
+	}
+}
+
+// serveDeploy Starts a deployment, which completes immediately, or returns the deployments
+func (s *Server) serveDeploy(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		now := time.Now().UnixNano() / int64(time.Millisecond)
+		d := map[string]interface{}{
+			"id":            newID(),
+			"name":          fmt.Sprintf("Deployment %d", len(s.deployments)+1),
+			"version":       newVersion(),
+			"state":         "DEPLOYED",
+			"statusMessage": "Deployed",
+			"queuedTime":    now,
+			"startTime":     now,
+			"endTime":       now,
+			"type":          "deploymentstatus",
+		}
+
+		s.deployments = append(s.deployments, d)
+		s.deployed = s.snapshot()
+
+		writeJSON(w, http.StatusOK, d)
+	case r.Method == http.MethodGet && id == "":
+		c := &collection{
+			endpoint: deployEndpoint,
+			items:    s.deployments,
+		}
+		s.list(w, r, c)
+	case r.Method == http.MethodGet:
+		for _, d := range s.deployments {
+			if d["id"] == id {
+				writeJSON(w, http.StatusOK, d)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "NotFound", "", fmt.Sprintf("deployment %s not found", id))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "", fmt.Sprintf("%s not allowed on %s", r.Method, deployEndpoint))
+	}
+}
@@ -0,0 +1,178 @@
+package ftdtest
+
+// Endpoints of the collections served by default
+const (
+	NetworksEndpoint           string = "object/networks"
+	NetworkGroupsEndpoint      string = "object/networkgroups"
+	TCPPortsEndpoint           string = "object/tcpports"
+	UDPPortsEndpoint           string = "object/udpports"
+	PortGroupsEndpoint         string = "object/portgroups"
+	ICMPv4PortsEndpoint        string = "object/icmpv4ports"
+	ICMPv6PortsEndpoint        string = "object/icmpv6ports"
+	ProtocolsEndpoint          string = "object/protocols"
+	SecurityZonesEndpoint      string = "object/securityzones"
+	URLsEndpoint               string = "object/urls"
+	AccessPoliciesEndpoint     string = "policy/accesspolicies"
+	ObjectNATPoliciesEndpoint  string = "policy/objectnatpolicies"
+	ManualNATPoliciesEndpoint  string = "policy/manualnatpolicies"
+	InterfacesEndpoint         string = "devices/default/interfaces"
+	VLANInterfacesEndpoint     string = "devices/default/vlaninterfaces"
+	StaticRouteEntriesEndpoint string = "devices/default/routing/virtualrouters/default/staticrouteentries"
+)
+
+// seed Registers the collections and the objects of a freshly installed device
+func (s *Server) seed() {
+	networks := s.addCollection(NetworksEndpoint, "networkobject")
+	anyIPv4 := s.seedItem(networks, map[string]interface{}{
+		"name":            "any-ipv4",
+		"subType":         "NETWORK",
+		"value":           "0.0.0.0/0",
+		"isSystemDefined": true,
+	})
+	anyIPv6 := s.seedItem(networks, map[string]interface{}{
+		"name":            "any-ipv6",
+		"subType":         "NETWORK",
+		"value":           "::/0",
+		"isSystemDefined": true,
+	})
+	// Name of the IPv4 any object on older releases
+	s.seedItem(networks, map[string]interface{}{
+		"name":            "0.0.0.0",
+		"subType":         "NETWORK",
+		"value":           "0.0.0.0/0",
+		"isSystemDefined": true,
+	})
+
+	s.seedItem(s.addCollection(NetworkGroupsEndpoint, "networkobjectgroup"), map[string]interface{}{
+		"name":            "any",
+		"objects":         []interface{}{ref(anyIPv4), ref(anyIPv6)},
+		"isSystemDefined": true,
+	})
+
+	tcp := s.addCollection(TCPPortsEndpoint, "tcpportobject")
+	var web []interface{}
+	for _, p := range []struct{ name, port string }{{"FTP", "21"}, {"SSH", "22"}, {"TELNET", "23"}, {"SMTP", "25"}, {"HTTP", "80"}, {"HTTPS", "443"}} {
+		item := s.seedItem(tcp, map[string]interface{}{
+			"name":            p.name,
+			"port":            p.port,
+			"isSystemDefined": true,
+		})
+
+		if p.port == "80" || p.port == "443" {
+			web = append(web, ref(item))
+		}
+	}
+
+	udp := s.addCollection(UDPPortsEndpoint, "udpportobject")
+	for _, p := range []struct{ name, port string }{{"DNS-over-UDP", "53"}, {"NTP-UDP", "123"}, {"SNMP", "161"}, {"SYSLOG", "514"}} {
+		s.seedItem(udp, map[string]interface{}{
+			"name":            p.name,
+			"port":            p.port,
+			"isSystemDefined": true,
+		})
+	}
+
+	s.seedItem(s.addCollection(PortGroupsEndpoint, "portobjectgroup"), map[string]interface{}{
+		"name":            "HTTP-HTTPS",
+		"objects":         web,
+		"isSystemDefined": true,
+	})
+
+	s.addCollection(ICMPv4PortsEndpoint, "icmpv4portobject")
+	s.addCollection(ICMPv6PortsEndpoint, "icmpv6portobject")
+	s.addCollection(ProtocolsEndpoint, "protocolobject")
+	s.addCollection(URLsEndpoint, "urlobject")
+
+	interfaces := s.addCollection(InterfacesEndpoint, "physicalinterface", child{"subinterfaces", "subinterface"})
+	interfaces.fixed = true
+	outside := s.seedItem(interfaces, map[string]interface{}{
+		"name":         "outside",
+		"hardwareName": "GigabitEthernet0/0",
+		"mode":         "ROUTED",
+		"mtu":          1500,
+		"enabled":      true,
+		"ipv4": map[string]interface{}{
+			"ipType":                "DHCP",
+			"defaultRouteUsingDHCP": true,
+			"type":                  "interfaceipv4",
+		},
+	})
+	inside := s.seedItem(interfaces, map[string]interface{}{
+		"name":         "inside",
+		"hardwareName": "GigabitEthernet0/1",
+		"mode":         "ROUTED",
+		"mtu":          1500,
+		"enabled":      true,
+		"ipv4": map[string]interface{}{
+			"ipType": "STATIC",
+			"ipAddress": map[string]interface{}{
+				"ipAddress": "192.168.1.1",
+				"netmask":   "255.255.255.0",
+				"type":      "haipv4address",
+			},
+			"type": "interfaceipv4",
+		},
+	})
+	s.seedItem(interfaces, map[string]interface{}{
+		"hardwareName": "GigabitEthernet0/2",
+		"mode":         "ROUTED",
+		"mtu":          1500,
+		"enabled":      false,
+	})
+
+	s.addCollection(VLANInterfacesEndpoint, "vlaninterface")
+	s.addCollection(StaticRouteEntriesEndpoint, "staticrouteentry")
+
+	zones := s.addCollection(SecurityZonesEndpoint, "securityzone")
+	s.seedItem(zones, map[string]interface{}{
+		"name":       "outside_zone",
+		"mode":       "ROUTED",
+		"interfaces": []interface{}{ref(outside)},
+	})
+	s.seedItem(zones, map[string]interface{}{
+		"name":       "inside_zone",
+		"mode":       "ROUTED",
+		"interfaces": []interface{}{ref(inside)},
+	})
+
+	// The default access policy is addressed as default
+	s.seedItem(s.addCollection(AccessPoliciesEndpoint, "accesspolicy", child{"accessrules", "accessrule"}), map[string]interface{}{
+		"id":   "default",
+		"name": "NGFW-Access-Policy",
+		"defaultAction": map[string]interface{}{
+			"action":         "DENY",
+			"eventLogAction": "LOG_NONE",
+			"type":           "accessdefaultaction",
+		},
+	})
+
+	s.seedItem(s.addCollection(ObjectNATPoliciesEndpoint, "objectnatpolicy", child{"objectnatrules", "objectnatrule"}), map[string]interface{}{
+		"name": "NGFW-Object-NAT-Policy",
+	})
+
+	manual := s.addCollection(ManualNATPoliciesEndpoint, "manualnatpolicy", child{"manualnatrules", "manualnatrule"})
+	s.seedItem(manual, map[string]interface{}{
+		"name": "NGFW-Before-Auto-NAT-Policy",
+	})
+	s.seedItem(manual, map[string]interface{}{
+		"name": "NGFW-After-Auto-NAT-Policy",
+	})
+}
+
+// seedItem Adds item at the end of c, stored as it would be once decoded from a request
+func (s *Server) seedItem(c *collection, item map[string]interface{}) map[string]interface{} {
+	item = s.newItem(c, copyItem(item))
+	c.insert(item, -1)
+
+	return item
+}
+
+// ref Returns a reference to item
+func ref(item map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      item["id"],
+		"name":    item["name"],
+		"version": item["version"],
+		"type":    item["type"],
+	}
+}
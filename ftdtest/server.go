@@ -0,0 +1,288 @@
+// Package ftdtest provides an in-memory fake of the FDM REST API for tests.
+//
+// The fake implements the token endpoint, the object, policy and device collections
+// used by goftd, paging, name filters, versioning, duplicate name errors,
+// pending changes and deployments. It starts with the system defined objects of a
+// freshly installed device, e.g. any-ipv4 and the default access policy.
+//
+//	srv := ftdtest.NewServer()
+//	defer srv.Close()
+//
+//	ftd, err := goftd.New(srv.Host(), goftd.WithPasswordGrant(ftdtest.Username, ftdtest.Password), goftd.WithInsecureTLS())
+//
+// Recorder and Replayer capture the traffic with a real device into a cassette and play it back,
+// see goftd.WithTransport.
+package ftdtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Username accepted by the password grant
+	Username string = "admin"
+	// Password accepted by the password grant
+	Password string = "Admin123"
+
+	basePath string = "/api/fdm/v1/"
+
+	tokenEndpoint          string = "fdm/token"
+	pendingChangesEndpoint string = "operational/pendingchanges"
+	deployEndpoint         string = "operational/deploy"
+
+	// tokenLifetime lifetime of the access tokens, in seconds
+	tokenLifetime int = 1800
+	// refreshTokenLifetime lifetime of the refresh tokens, in seconds
+	refreshTokenLifetime int = 2400
+)
+
+// Server A fake FDM listening on a local TLS port
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	// issued tokens and their expiration
+	accessTokens  map[string]time.Time
+	refreshTokens map[string]time.Time
+
+	// collections by endpoint, e.g. object/networks
+	collections map[string]*collection
+	// deployed configuration, pending changes are the difference with collections
+	deployed map[string]*collection
+	// deployments in the order they were requested
+	deployments []map[string]interface{}
+}
+
+// NewServer Starts a fake FDM seeded with the system defined objects, Close stops it
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.StartTLS()
+
+	return s
+}
+
+// NewUnstartedServer Returns a fake FDM that is not listening yet, call StartTLS to start it
+func NewUnstartedServer() *Server {
+	s := &Server{
+		accessTokens:  make(map[string]time.Time),
+		refreshTokens: make(map[string]time.Time),
+		collections:   make(map[string]*collection),
+	}
+
+	s.seed()
+	s.deployed = s.snapshot()
+	s.Server = httptest.NewUnstartedServer(s)
+
+	return s
+}
+
+// Host Returns the host:port to give to goftd.New
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// AddCollection Registers an additional collection, e.g. object/urls, with its initial items.
+// Items without ID get one and are deployed, it is safe to call on a running server.
+func (s *Server) AddCollection(endpoint, itemType string, items ...map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.addCollection(endpoint, itemType)
+	for _, item := range items {
+		s.seedItem(c, item)
+	}
+	s.deployed[endpoint] = c.copy()
+}
+
+// ServeHTTP Dispatches a request to the token endpoint, the operational endpoints or a collection
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, basePath) {
+		writeError(w, http.StatusNotFound, "NotFound", "", fmt.Sprintf("unknown path %s", r.URL.Path))
+		return
+	}
+	endpoint := strings.Trim(strings.TrimPrefix(r.URL.Path, basePath), "/")
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "", err.Error())
+			return
+		}
+
+		if len(data) > 0 {
+			err = json.Unmarshal(data, &body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "invalidJson", err.Error())
+				return
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if endpoint == tokenEndpoint {
+		s.serveToken(w, r, body)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "", "invalid or expired access token")
+		return
+	}
+
+	switch {
+	case endpoint == pendingChangesEndpoint:
+		s.servePendingChanges(w, r)
+	case endpoint == deployEndpoint || strings.HasPrefix(endpoint, deployEndpoint+"/"):
+		s.serveDeploy(w, r, strings.TrimPrefix(strings.TrimPrefix(endpoint, deployEndpoint), "/"))
+	default:
+		s.serveCollection(w, r, endpoint, body)
+	}
+}
+
+// serveToken Implements the password, refresh_token, custom_token and revoke_token grants
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	if r.Method != http.MethodPost {
+		writeTokenError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	grant, _ := body["grant_type"].(string)
+	switch grant {
+	case "password":
+		if body["username"] != Username || body["password"] != Password {
+			writeTokenError(w, http.StatusBadRequest, "invalid username or password")
+			return
+		}
+	case "refresh_token":
+		token, _ := body["refresh_token"].(string)
+		if !valid(s.refreshTokens, token) {
+			writeTokenError(w, http.StatusBadRequest, "invalid refresh token")
+			return
+		}
+		delete(s.refreshTokens, token)
+	case "custom_token":
+		token, _ := body["access_token"].(string)
+		if !valid(s.accessTokens, token) {
+			writeTokenError(w, http.StatusBadRequest, "invalid access token")
+			return
+		}
+	case "revoke_token":
+		token, _ := body["access_token"].(string)
+		if !valid(s.accessTokens, token) {
+			writeTokenError(w, http.StatusBadRequest, "invalid access token")
+			return
+		}
+
+		revoked, _ := body["token_to_revoke"].(string)
+		delete(s.accessTokens, revoked)
+		delete(s.refreshTokens, revoked)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	default:
+		writeTokenError(w, http.StatusBadRequest, fmt.Sprintf("unsupported grant type %q", grant))
+		return
+	}
+
+	expiresIn := lifetime(body["desired_expires_in"], tokenLifetime)
+	refreshExpiresIn := lifetime(body["desired_refresh_expires_in"], refreshTokenLifetime)
+
+	access := newToken()
+	refresh := newToken()
+	s.accessTokens[access] = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	s.refreshTokens[refresh] = time.Now().Add(time.Duration(refreshExpiresIn) * time.Second)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":       access,
+		"refresh_token":      refresh,
+		"expires_in":         expiresIn,
+		"refresh_expires_in": refreshExpiresIn,
+		"token_type":         "Bearer",
+	})
+}
+
+// authorized Returns true if the request carries a valid access token
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	return valid(s.accessTokens, token)
+}
+
+// valid Returns true if token was issued and has not expired
+func valid(tokens map[string]time.Time, token string) bool {
+	expiresAt, ok := tokens[token]
+
+	return ok && token != "" && time.Now().Before(expiresAt)
+}
+
+// lifetime Returns the lifetime requested in a grant, or def when there is none
+func lifetime(v interface{}, def int) int {
+	if n, ok := v.(float64); ok && n > 0 {
+		return int(n)
+	}
+
+	return def
+}
+
+// newToken Returns a random token
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// newID Returns a random UUID
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// writeJSON Writes v as the body of the response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError Writes an error in the format of the FDM API
+func writeError(w http.ResponseWriter, status int, key, code, description string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"severity": "ERROR",
+			"key":      key,
+			"messages": []map[string]interface{}{
+				{
+					"description": description,
+					"code":        code,
+					"location":    "",
+				},
+			},
+		},
+	})
+}
+
+// writeTokenError Writes an error in the format of the token endpoint
+func writeTokenError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"status":  status,
+		"message": message,
+	})
+}
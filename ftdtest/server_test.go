@@ -0,0 +1,216 @@
+package ftdtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// client A minimal FDM client authenticated against s
+type client struct {
+	t     *testing.T
+	s     *Server
+	token string
+}
+
+func newClient(t *testing.T, s *Server) *client {
+	c := &client{t: t, s: s}
+
+	var token map[string]interface{}
+	status := c.do("POST", tokenEndpoint, map[string]interface{}{
+		"grant_type": "password",
+		"username":   Username,
+		"password":   Password,
+	}, &token)
+	if status != http.StatusOK {
+		t.Fatalf("expecting status 200 for the token, got %d\n", status)
+	}
+
+	c.token = token["access_token"].(string)
+
+	return c
+}
+
+func (c *client) do(method, endpoint string, body, v interface{}) int {
+	c.t.Helper()
+
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+
+	req, err := http.NewRequest(method, c.s.URL+basePath+endpoint, bytes.NewReader(data))
+	if err != nil {
+		c.t.Fatalf("error: %s\n", err)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.s.Client().Do(req)
+	if err != nil {
+		c.t.Fatalf("error: %s\n", err)
+	}
+	defer resp.Body.Close()
+
+	if v != nil {
+		json.NewDecoder(resp.Body).Decode(v)
+	}
+
+	return resp.StatusCode
+}
+
+func TestUnauthorized(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := &client{t: t, s: s}
+	if status := c.do("GET", NetworksEndpoint, nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("expecting status 401, got %d\n", status)
+	}
+}
+
+func TestPaging(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newClient(t, s)
+	for i := 0; i < 12; i++ {
+		status := c.do("POST", NetworksEndpoint, map[string]interface{}{
+			"name":    fmt.Sprintf("testObj%03d", i),
+			"subType": "HOST",
+			"value":   fmt.Sprintf("192.0.2.%d", i),
+			"type":    "networkobject",
+		}, nil)
+		if status != http.StatusOK {
+			t.Fatalf("expecting status 200, got %d\n", status)
+		}
+	}
+
+	var p struct {
+		Items  []map[string]interface{} `json:"items"`
+		Paging struct {
+			Next  []string `json:"next"`
+			Count int      `json:"count"`
+		} `json:"paging"`
+	}
+
+	c.do("GET", NetworksEndpoint+"?filter=name:testObj&limit=5&offset=10", nil, &p)
+	if len(p.Items) != 2 || p.Paging.Count != 12 || len(p.Paging.Next) != 0 {
+		t.Errorf("expecting the last 2 of 12 objects, got %d of %d\n", len(p.Items), p.Paging.Count)
+	}
+
+	c.do("GET", NetworksEndpoint+"?filter=name:testObj&limit=5", nil, &p)
+	if len(p.Items) != 5 || len(p.Paging.Next) != 1 {
+		t.Errorf("expecting 5 objects and a next page, got %d\n", len(p.Items))
+	}
+}
+
+func TestDuplicateAndVersion(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newClient(t, s)
+
+	obj := map[string]interface{}{
+		"name":    "testObj001",
+		"subType": "HOST",
+		"value":   "192.0.2.1",
+		"type":    "networkobject",
+	}
+
+	var created map[string]interface{}
+	c.do("POST", NetworksEndpoint, obj, &created)
+
+	var e struct {
+		Error struct {
+			Messages []struct {
+				Code string `json:"code"`
+			} `json:"messages"`
+		} `json:"error"`
+	}
+
+	status := c.do("POST", NetworksEndpoint, obj, &e)
+	if status != http.StatusUnprocessableEntity || e.Error.Messages[0].Code != "duplicateName" {
+		t.Errorf("expecting duplicateName, got %d %+v\n", status, e)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s", NetworksEndpoint, created["id"])
+	created["value"] = "192.0.2.2"
+
+	var updated map[string]interface{}
+	status = c.do("PUT", endpoint, created, &updated)
+	if status != http.StatusOK || updated["version"] == created["version"] {
+		t.Errorf("expecting a new version, got %d %v\n", status, updated["version"])
+	}
+
+	// created holds the previous version
+	status = c.do("PUT", endpoint, created, &e)
+	if status != http.StatusUnprocessableEntity || e.Error.Messages[0].Code != "invalidVersion" {
+		t.Errorf("expecting invalidVersion, got %d %+v\n", status, e)
+	}
+}
+
+func TestDeleteInUse(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newClient(t, s)
+
+	var n, g map[string]interface{}
+	c.do("POST", NetworksEndpoint, map[string]interface{}{
+		"name":    "testObj001",
+		"subType": "HOST",
+		"value":   "192.0.2.1",
+		"type":    "networkobject",
+	}, &n)
+	c.do("POST", NetworkGroupsEndpoint, map[string]interface{}{
+		"name":    "testObjGroup001",
+		"objects": []interface{}{n},
+		"type":    "networkobjectgroup",
+	}, &g)
+
+	if status := c.do("DELETE", fmt.Sprintf("%s/%s", NetworksEndpoint, n["id"]), nil, nil); status != http.StatusUnprocessableEntity {
+		t.Errorf("expecting status 422 for an object in use, got %d\n", status)
+	}
+
+	if status := c.do("DELETE", fmt.Sprintf("%s/%s", NetworkGroupsEndpoint, g["id"]), nil, nil); status != http.StatusNoContent {
+		t.Errorf("expecting status 204, got %d\n", status)
+	}
+
+	if status := c.do("DELETE", fmt.Sprintf("%s/%s", NetworksEndpoint, n["id"]), nil, nil); status != http.StatusNoContent {
+		t.Errorf("expecting status 204, got %d\n", status)
+	}
+}
+
+func TestPendingChanges(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newClient(t, s)
+	c.do("POST", NetworksEndpoint, map[string]interface{}{
+		"name":    "testObj001",
+		"subType": "HOST",
+		"value":   "192.0.2.1",
+		"type":    "networkobject",
+	}, nil)
+
+	var p struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+
+	c.do("GET", pendingChangesEndpoint, nil, &p)
+	if len(p.Items) != 1 || p.Items[0]["changeType"] != "ADD" {
+		t.Errorf("expecting 1 ADD, got %+v\n", p.Items)
+	}
+
+	c.do("POST", deployEndpoint, nil, nil)
+
+	c.do("GET", pendingChangesEndpoint, nil, &p)
+	if len(p.Items) != 0 {
+		t.Errorf("expecting no pending changes after deploy, got %d\n", len(p.Items))
+	}
+}
@@ -0,0 +1,86 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Geolocation Countries and continents, reference it in the source or destination networks of an access rule
+type Geolocation struct {
+	ReferenceObject
+	Description     string             `json:"description,omitempty"`
+	Locations       []*ReferenceObject `json:"locations,omitempty"`
+	IsSystemDefined bool               `json:"isSystemDefined,omitempty"`
+	Links           *Links             `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (g *Geolocation) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      g.ID,
+		Name:    g.Name,
+		Version: g.Version,
+		Type:    g.Type,
+	}
+
+	return &r
+}
+
+// GetGeolocations Get a list of geolocations, a limit of 0 returns all of them
+func (f *FTD) GetGeolocations(limit int) ([]*Geolocation, error) {
+	return f.GetGeolocationsContext(context.Background(), limit)
+}
+
+// GetGeolocationsContext Same as GetGeolocations, ctx cancels the requests
+func (f *FTD) GetGeolocationsContext(ctx context.Context, limit int) ([]*Geolocation, error) {
+	var err error
+	var retval []*Geolocation
+
+	err = f.iterGeolocations(ctx, limit, func(v *Geolocation) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterGeolocations Calls fn for every geolocation, fetching one page at a time
+func (f *FTD) IterGeolocations(ctx context.Context, fn func(*Geolocation) error) error {
+	return f.iterGeolocations(ctx, 0, fn)
+}
+
+func (f *FTD) iterGeolocations(ctx context.Context, limit int, fn func(*Geolocation) error) error {
+	return f.iterate(ctx, apiGeolocationsEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *Geolocation
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetGeolocationByName Get a geolocation by name
+func (f *FTD) GetGeolocationByName(name string) (*Geolocation, error) {
+	return f.GetGeolocationByNameContext(context.Background(), name)
+}
+
+// GetGeolocationByNameContext Same as GetGeolocationByName, ctx cancels the requests
+func (f *FTD) GetGeolocationByNameContext(ctx context.Context, name string) (*Geolocation, error) {
+	var v *Geolocation
+
+	err := f.findByName(ctx, apiGeolocationsEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
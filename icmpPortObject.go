@@ -0,0 +1,241 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ICMPPortObject Represents an ICMPv4 or ICMPv6 type and code, Type selects the version.
+// An empty ICMP type or code matches any.
+type ICMPPortObject struct {
+	ReferenceObject
+	Description     string `json:"description,omitempty"`
+	ICMPv4Type      string `json:"icmpv4Type,omitempty"`
+	ICMPv4Code      string `json:"icmpv4Code,omitempty"`
+	ICMPv6Type      string `json:"icmpv6Type,omitempty"`
+	ICMPv6Code      string `json:"icmpv6Code,omitempty"`
+	IsSystemDefined bool   `json:"isSystemDefined,omitempty"`
+	Links           *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (p *ICMPPortObject) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      p.ID,
+		Name:    p.Name,
+		Version: p.Version,
+		Type:    p.Type,
+	}
+
+	return &r
+}
+
+// icmpPortEndpoint Returns the endpoint of an ICMP port object type
+func icmpPortEndpoint(objectType string) (string, error) {
+	switch objectType {
+	case TypeICMPv4PortObject:
+		return apiICMPv4PortObjectsEndpoint, nil
+	case TypeICMPv6PortObject:
+		return apiICMPv6PortObjectsEndpoint, nil
+	}
+
+	return "", fmt.Errorf("unknown ICMP port type: %s", objectType)
+}
+
+func (f *FTD) iterICMPPortObjects(ctx context.Context, objectType string, query map[string]string, limit int, fn func(*ICMPPortObject) error) error {
+	endpoint, err := icmpPortEndpoint(objectType)
+	if err != nil {
+		return err
+	}
+
+	return f.iterate(ctx, endpoint, query, limit, func(item json.RawMessage) error {
+		var p *ICMPPortObject
+
+		err := json.Unmarshal(item, &p)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(p)
+	})
+}
+
+func (f *FTD) getICMPPortObjects(ctx context.Context, objectType string, query map[string]string, limit int) ([]*ICMPPortObject, error) {
+	var err error
+	var retval []*ICMPPortObject
+
+	err = f.iterICMPPortObjects(ctx, objectType, query, limit, func(p *ICMPPortObject) error {
+		retval = append(retval, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// GetICMPv4PortObjects Get a list of icmpv4 ports, a limit of 0 returns all of them
+func (f *FTD) GetICMPv4PortObjects(limit int) ([]*ICMPPortObject, error) {
+	return f.GetICMPv4PortObjectsContext(context.Background(), limit)
+}
+
+// GetICMPv4PortObjectsContext Same as GetICMPv4PortObjects, ctx cancels the requests
+func (f *FTD) GetICMPv4PortObjectsContext(ctx context.Context, limit int) ([]*ICMPPortObject, error) {
+	return f.getICMPPortObjects(ctx, TypeICMPv4PortObject, nil, limit)
+}
+
+// GetICMPv6PortObjects Get a list of icmpv6 ports, a limit of 0 returns all of them
+func (f *FTD) GetICMPv6PortObjects(limit int) ([]*ICMPPortObject, error) {
+	return f.GetICMPv6PortObjectsContext(context.Background(), limit)
+}
+
+// GetICMPv6PortObjectsContext Same as GetICMPv6PortObjects, ctx cancels the requests
+func (f *FTD) GetICMPv6PortObjectsContext(ctx context.Context, limit int) ([]*ICMPPortObject, error) {
+	return f.getICMPPortObjects(ctx, TypeICMPv6PortObject, nil, limit)
+}
+
+// IterICMPv4PortObjects Calls fn for every icmpv4 port, fetching one page at a time
+func (f *FTD) IterICMPv4PortObjects(ctx context.Context, fn func(*ICMPPortObject) error) error {
+	return f.iterICMPPortObjects(ctx, TypeICMPv4PortObject, nil, 0, fn)
+}
+
+// IterICMPv6PortObjects Calls fn for every icmpv6 port, fetching one page at a time
+func (f *FTD) IterICMPv6PortObjects(ctx context.Context, fn func(*ICMPPortObject) error) error {
+	return f.iterICMPPortObjects(ctx, TypeICMPv6PortObject, nil, 0, fn)
+}
+
+// CreateICMPv4PortObject Creates a new icmpv4 port
+func (f *FTD) CreateICMPv4PortObject(p *ICMPPortObject, duplicateAction int) error {
+	return f.CreateICMPv4PortObjectContext(context.Background(), p, duplicateAction)
+}
+
+// CreateICMPv4PortObjectContext Same as CreateICMPv4PortObject, ctx cancels the requests
+func (f *FTD) CreateICMPv4PortObjectContext(ctx context.Context, p *ICMPPortObject, duplicateAction int) error {
+	p.Type = TypeICMPv4PortObject
+	return f.createICMPPortObject(ctx, p, duplicateAction)
+}
+
+// CreateICMPv6PortObject Creates a new icmpv6 port
+func (f *FTD) CreateICMPv6PortObject(p *ICMPPortObject, duplicateAction int) error {
+	return f.CreateICMPv6PortObjectContext(context.Background(), p, duplicateAction)
+}
+
+// CreateICMPv6PortObjectContext Same as CreateICMPv6PortObject, ctx cancels the requests
+func (f *FTD) CreateICMPv6PortObjectContext(ctx context.Context, p *ICMPPortObject, duplicateAction int) error {
+	p.Type = TypeICMPv6PortObject
+	return f.createICMPPortObject(ctx, p, duplicateAction)
+}
+
+func (f *FTD) createICMPPortObject(ctx context.Context, p *ICMPPortObject, duplicateAction int) error {
+	var err error
+
+	endpoint, err := icmpPortEndpoint(p.Type)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.PostContext(ctx, endpoint, p)
+	if err != nil {
+		if IsDuplicate(err) {
+			if f.debug {
+				f.logger.Warningf("This is a duplicate\n")
+			}
+			if duplicateAction == DuplicateActionError {
+				return err
+			}
+		} else {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	o := new(ICMPPortObject)
+	err = f.findCreated(ctx, endpoint, p.Name, o)
+	if err != nil {
+		return err
+	}
+
+	switch duplicateAction {
+	case DuplicateActionReplace:
+		o.ICMPv4Type = p.ICMPv4Type
+		o.ICMPv4Code = p.ICMPv4Code
+		o.ICMPv6Type = p.ICMPv6Type
+		o.ICMPv6Code = p.ICMPv6Code
+
+		err = f.UpdateICMPPortObjectContext(ctx, o)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	*p = *o
+	return nil
+}
+
+// UpdateICMPPortObject Updates an icmp port
+func (f *FTD) UpdateICMPPortObject(p *ICMPPortObject) error {
+	return f.UpdateICMPPortObjectContext(context.Background(), p)
+}
+
+// UpdateICMPPortObjectContext Same as UpdateICMPPortObject, ctx cancels the requests
+func (f *FTD) UpdateICMPPortObjectContext(ctx context.Context, p *ICMPPortObject) error {
+	var err error
+
+	endpoint, err := icmpPortEndpoint(p.Type)
+	if err != nil {
+		return err
+	}
+
+	data, err := f.PutContext(ctx, fmt.Sprintf("%s/%s", endpoint, p.ID), p)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &p)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteICMPPortObject Delete an icmp port
+func (f *FTD) DeleteICMPPortObject(p *ICMPPortObject) error {
+	return f.DeleteICMPPortObjectContext(context.Background(), p)
+}
+
+// DeleteICMPPortObjectContext Same as DeleteICMPPortObject, ctx cancels the requests
+func (f *FTD) DeleteICMPPortObjectContext(ctx context.Context, p *ICMPPortObject) error {
+	var err error
+
+	endpoint, err := icmpPortEndpoint(p.Type)
+	if err != nil {
+		return err
+	}
+
+	err = f.DeleteContext(ctx, fmt.Sprintf("%s/%s", endpoint, p.ID))
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
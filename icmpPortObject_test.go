@@ -0,0 +1,82 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestICMPPort(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	for _, v := range []struct {
+		objectType string
+		create     func(*ICMPPortObject, int) error
+		list       func(int) ([]*ICMPPortObject, error)
+	}{
+		{TypeICMPv4PortObject, ftd.CreateICMPv4PortObject, ftd.GetICMPv4PortObjects},
+		{TypeICMPv6PortObject, ftd.CreateICMPv6PortObject, ftd.GetICMPv6PortObjects},
+	} {
+		// the second name is a prefix of the first, the lookup after the creation must not mix them up
+		var created []*ICMPPortObject
+		for _, name := range []string{"testICMPEcho10", "testICMPEcho1"} {
+			p := new(ICMPPortObject)
+			p.Name = name
+			if v.objectType == TypeICMPv4PortObject {
+				p.ICMPv4Type = "8"
+			} else {
+				p.ICMPv6Type = "128"
+			}
+
+			err = v.create(p, DuplicateActionError)
+			if err != nil {
+				t.Errorf("%s %s: error: %s\n", v.objectType, name, err)
+				break
+			}
+			created = append(created, p)
+
+			if p.ID == "" || p.Version == "" || p.Name != name || p.Type != v.objectType {
+				t.Errorf("%s %s: unexpected object %+v\n", v.objectType, name, p)
+			}
+		}
+
+		for _, p1 := range created {
+			p1.Description = "echo request"
+			err = ftd.UpdateICMPPortObject(p1)
+			if err != nil {
+				t.Errorf("%s %s: error: %s\n", v.objectType, p1.Name, err)
+			}
+
+			p2 := new(ICMPPortObject)
+			p2.Name = p1.Name
+			err = v.create(p2, DuplicateActionDoNothing)
+			if err != nil {
+				t.Errorf("%s %s: error: %s\n", v.objectType, p1.Name, err)
+			} else if p2.ID != p1.ID || p2.Description != "echo request" {
+				t.Errorf("%s %s: expecting the updated object %+v, got %+v\n", v.objectType, p1.Name, p1, p2)
+			}
+
+			err = ftd.DeleteICMPPortObject(p1)
+			if err != nil {
+				t.Errorf("%s %s: error: %s\n", v.objectType, p1.Name, err)
+			}
+		}
+
+		ports, err := v.list(0)
+		if err != nil {
+			t.Errorf("%s: error: %s\n", v.objectType, err)
+			return
+		}
+		for _, p := range ports {
+			for _, p1 := range created {
+				if p.ID == p1.ID {
+					t.Errorf("%s %s: still there after its deletion\n", v.objectType, p1.Name)
+				}
+			}
+		}
+	}
+}
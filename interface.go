@@ -0,0 +1,268 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// HAIPv4Address IPv4 address of an interface
+type HAIPv4Address struct {
+	IPAddress        string `json:"ipAddress,omitempty"`
+	Netmask          string `json:"netmask,omitempty"`
+	StandbyIPAddress string `json:"standbyIpAddress,omitempty"`
+	Type             string `json:"type"`
+}
+
+// InterfaceIPv4 IPv4 addressing of an interface, static or DHCP
+type InterfaceIPv4 struct {
+	IPType                string         `json:"ipType,omitempty"`
+	DefaultRouteUsingDHCP bool           `json:"defaultRouteUsingDHCP,omitempty"`
+	IPAddress             *HAIPv4Address `json:"ipAddress,omitempty"`
+	Type                  string         `json:"type"`
+}
+
+// HAIPv6Address IPv6 address of an interface
+type HAIPv6Address struct {
+	IPAddress        string `json:"ipAddress,omitempty"`
+	PrefixLength     int    `json:"prefixLength,omitempty"`
+	StandbyIPAddress string `json:"standbyIpAddress,omitempty"`
+	Type             string `json:"type"`
+}
+
+// InterfaceIPv6 IPv6 addressing of an interface, static or autoconfiguration
+type InterfaceIPv6 struct {
+	Enabled              bool             `json:"enabled"`
+	AutoConfig           bool             `json:"autoConfig,omitempty"`
+	DHCPForManagedConfig bool             `json:"dhcpForManagedConfig,omitempty"`
+	DHCPForOtherConfig   bool             `json:"dhcpForOtherConfig,omitempty"`
+	IPAddresses          []*HAIPv6Address `json:"ipAddresses,omitempty"`
+	Type                 string           `json:"type"`
+}
+
+// NewStaticIPv4 Returns a static IPv4 addressing from a CIDR, e.g. 192.168.1.1/24
+func NewStaticIPv4(cidr string) (*InterfaceIPv4, error) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("invalid IPv4 CIDR: %s", cidr)
+	}
+
+	r := InterfaceIPv4{
+		IPType: IPTypeStatic,
+		IPAddress: &HAIPv4Address{
+			IPAddress: ip.String(),
+			Netmask:   net.IP(ipNet.Mask).String(),
+			Type:      "haipv4address",
+		},
+		Type: "interfaceipv4",
+	}
+
+	return &r, nil
+}
+
+// NewDHCPIPv4 Returns a DHCP IPv4 addressing, defaultRoute installs the default route learned from the server
+func NewDHCPIPv4(defaultRoute bool) *InterfaceIPv4 {
+	r := InterfaceIPv4{
+		IPType:                IPTypeDHCP,
+		DefaultRouteUsingDHCP: defaultRoute,
+		Type:                  "interfaceipv4",
+	}
+
+	return &r
+}
+
+// NewStaticIPv6 Returns a static IPv6 addressing from CIDRs, e.g. 2001:db8::1/64
+func NewStaticIPv6(cidrs ...string) (*InterfaceIPv6, error) {
+	r := InterfaceIPv6{
+		Enabled: true,
+		Type:    "interfaceipv6",
+	}
+
+	for _, cidr := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 CIDR: %s", cidr)
+		}
+
+		ones, _ := ipNet.Mask.Size()
+		r.IPAddresses = append(r.IPAddresses, &HAIPv6Address{
+			IPAddress:    ip.String(),
+			PrefixLength: ones,
+			Type:         "haipv6address",
+		})
+	}
+
+	return &r, nil
+}
+
+// NewAutoConfigIPv6 Returns an IPv6 addressing using stateless autoconfiguration
+func NewAutoConfigIPv6() *InterfaceIPv6 {
+	r := InterfaceIPv6{
+		Enabled:    true,
+		AutoConfig: true,
+		Type:       "interfaceipv6",
+	}
+
+	return &r
+}
+
+// PhysicalInterface A physical data interface of the device
+type PhysicalInterface struct {
+	ReferenceObject
+	Description      string         `json:"description,omitempty"`
+	HardwareName     string         `json:"hardwareName,omitempty"`
+	MonitorInterface bool           `json:"monitorInterface,omitempty"`
+	IPv4             *InterfaceIPv4 `json:"ipv4,omitempty"`
+	IPv6             *InterfaceIPv6 `json:"ipv6,omitempty"`
+	ManagementOnly   bool           `json:"managementOnly,omitempty"`
+	Mode             string         `json:"mode,omitempty"`
+	MTU              int            `json:"mtu,omitempty"`
+	Enabled          bool           `json:"enabled"`
+	MACAddress       string         `json:"macAddress,omitempty"`
+	LinkState        string         `json:"linkState,omitempty"`
+	Links            *Links         `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (i *PhysicalInterface) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      i.ID,
+		Name:    i.Name,
+		Version: i.Version,
+		Type:    i.Type,
+	}
+
+	return &r
+}
+
+// GetPhysicalInterfaces Get a list of physical interfaces, a limit of 0 returns all of them
+func (f *FTD) GetPhysicalInterfaces(limit int) ([]*PhysicalInterface, error) {
+	return f.GetPhysicalInterfacesContext(context.Background(), limit)
+}
+
+// GetPhysicalInterfacesContext Same as GetPhysicalInterfaces, ctx cancels the requests
+func (f *FTD) GetPhysicalInterfacesContext(ctx context.Context, limit int) ([]*PhysicalInterface, error) {
+	var err error
+	var retval []*PhysicalInterface
+
+	err = f.iterPhysicalInterfaces(ctx, limit, func(i *PhysicalInterface) error {
+		retval = append(retval, i)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterPhysicalInterfaces Calls fn for every physical interface, fetching one page at a time
+func (f *FTD) IterPhysicalInterfaces(ctx context.Context, fn func(*PhysicalInterface) error) error {
+	return f.iterPhysicalInterfaces(ctx, 0, fn)
+}
+
+func (f *FTD) iterPhysicalInterfaces(ctx context.Context, limit int, fn func(*PhysicalInterface) error) error {
+	return f.iterate(ctx, apiInterfacesEndpoint, nil, limit, func(item json.RawMessage) error {
+		var i *PhysicalInterface
+
+		err := json.Unmarshal(item, &i)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(i)
+	})
+}
+
+// GetPhysicalInterfaceByID Get a physical interface by ID
+func (f *FTD) GetPhysicalInterfaceByID(id string) (*PhysicalInterface, error) {
+	return f.GetPhysicalInterfaceByIDContext(context.Background(), id)
+}
+
+// GetPhysicalInterfaceByIDContext Same as GetPhysicalInterfaceByID, ctx cancels the requests
+func (f *FTD) GetPhysicalInterfaceByIDContext(ctx context.Context, id string) (*PhysicalInterface, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiInterfacesEndpoint, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *PhysicalInterface
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetPhysicalInterfaceByHardwareName Get a physical interface by hardware name, e.g. GigabitEthernet0/1
+func (f *FTD) GetPhysicalInterfaceByHardwareName(hardwareName string) (*PhysicalInterface, error) {
+	return f.GetPhysicalInterfaceByHardwareNameContext(context.Background(), hardwareName)
+}
+
+// GetPhysicalInterfaceByHardwareNameContext Same as GetPhysicalInterfaceByHardwareName, ctx cancels the requests
+func (f *FTD) GetPhysicalInterfaceByHardwareNameContext(ctx context.Context, hardwareName string) (*PhysicalInterface, error) {
+	var retval *PhysicalInterface
+
+	err := f.iterPhysicalInterfaces(ctx, 0, func(i *PhysicalInterface) error {
+		if i.HardwareName == hardwareName {
+			retval = i
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("interface %s: %w", hardwareName, ErrNotFound)
+	}
+
+	return retval, nil
+}
+
+// UpdatePhysicalInterface Updates a physical interface, physical interfaces can't be created or deleted
+func (f *FTD) UpdatePhysicalInterface(i *PhysicalInterface) error {
+	return f.UpdatePhysicalInterfaceContext(context.Background(), i)
+}
+
+// UpdatePhysicalInterfaceContext Same as UpdatePhysicalInterface, ctx cancels the requests
+func (f *FTD) UpdatePhysicalInterfaceContext(ctx context.Context, i *PhysicalInterface) error {
+	var err error
+
+	i.Type = "physicalinterface"
+
+	endpoint := fmt.Sprintf("%s/%s", apiInterfacesEndpoint, i.ID)
+	data, err := f.PutContext(ctx, endpoint, i)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &i)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestNewStaticIPv4(t *testing.T) {
+	ip, err := NewStaticIPv4("192.168.1.1/24")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if ip.IPType != IPTypeStatic {
+		t.Errorf("expecting %s, got %s\n", IPTypeStatic, ip.IPType)
+	}
+
+	if ip.IPAddress.IPAddress != "192.168.1.1" || ip.IPAddress.Netmask != "255.255.255.0" {
+		t.Errorf("unexpected address %s/%s\n", ip.IPAddress.IPAddress, ip.IPAddress.Netmask)
+	}
+
+	for _, cidr := range []string{"192.168.1.1", "2001:db8::1/64"} {
+		_, err = NewStaticIPv4(cidr)
+		if err == nil {
+			t.Errorf("expecting an error for %s\n", cidr)
+		}
+	}
+}
+
+func TestNewStaticIPv6(t *testing.T) {
+	ip, err := NewStaticIPv6("2001:db8::1/64", "2001:db8:1::1/48")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(ip.IPAddresses) != 2 {
+		t.Errorf("expecting 2 addresses, got %d\n", len(ip.IPAddresses))
+		return
+	}
+
+	if ip.IPAddresses[0].IPAddress != "2001:db8::1" || ip.IPAddresses[0].PrefixLength != 64 {
+		t.Errorf("unexpected address %s/%d\n", ip.IPAddresses[0].IPAddress, ip.IPAddresses[0].PrefixLength)
+	}
+
+	_, err = NewStaticIPv6("192.168.1.1/24")
+	if err == nil {
+		t.Errorf("expecting an error\n")
+	}
+}
+
+func TestPhysicalInterface(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	i, err := ftd.GetPhysicalInterfaces(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(i) == 0 {
+		t.Errorf("no physical interface\n")
+		return
+	}
+
+	i2, err := ftd.GetPhysicalInterfaceByHardwareName(i[0].HardwareName)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if i2.ID != i[0].ID {
+		t.Errorf("expected ID %s, got %s\n", i[0].ID, i2.ID)
+	}
+
+	_, err = ftd.GetPhysicalInterfaceByHardwareName("testInterface001")
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+}
+
+func TestSubInterface(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	i, err := ftd.GetPhysicalInterfaces(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(i) == 0 {
+		t.Errorf("no physical interface\n")
+		return
+	}
+	p := i[0]
+
+	ipv4, err := NewStaticIPv4("192.0.2.129/26")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	s := &SubInterface{
+		HardwareName:   p.HardwareName + ".3900",
+		SubInterfaceID: 3900,
+		VLANID:         3900,
+		IPv4:           ipv4,
+		Enabled:        true,
+	}
+	s.Name = "testSub3900"
+
+	err = ftd.CreateSubInterface(p.ID, s)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if s.ID == "" || s.Version == "" || s.parent != p.ID {
+		t.Errorf("sub-interface is not populated correctly: %+v\n", s)
+	}
+
+	s2, err := ftd.GetSubInterfaceByHardwareName(s.HardwareName)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		ftd.DeleteSubInterface(s)
+		return
+	}
+
+	if s2.ID != s.ID || s2.VLANID != s.VLANID {
+		t.Errorf("expected %s on VLAN %d, got %s on VLAN %d\n", s.ID, s.VLANID, s2.ID, s2.VLANID)
+	}
+
+	s2.MTU = 1400
+	err = ftd.UpdateSubInterface(s2)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	s3, err := ftd.GetSubInterfaceByID(p.ID, s.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if s3.MTU != 1400 {
+		t.Errorf("expecting MTU 1400, got %d\n", s3.MTU)
+	}
+
+	err = ftd.DeleteSubInterface(s2)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ftd.GetSubInterfaceByID(p.ID, s.ID)
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+}
+
+func TestVLANInterface(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	ipv4, err := NewStaticIPv4("192.0.2.193/26")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	v := &VLANInterface{
+		HardwareName: "Vlan3901",
+		VLANID:       3901,
+		IPv4:         ipv4,
+		Enabled:      true,
+	}
+	v.Name = "testVlan3901"
+
+	err = ftd.CreateVLANInterface(v)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if v.ID == "" || v.Version == "" {
+		t.Errorf("ID of value is not populated correctly\n")
+	}
+
+	v2, err := ftd.GetVLANInterfaceByHardwareName(v.HardwareName)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		ftd.DeleteVLANInterface(v)
+		return
+	}
+
+	if v2.ID != v.ID || v2.VLANID != v.VLANID {
+		t.Errorf("expected %s on VLAN %d, got %s on VLAN %d\n", v.ID, v.VLANID, v2.ID, v2.VLANID)
+	}
+
+	v2.MTU = 1400
+	err = ftd.UpdateVLANInterface(v2)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	v3, err := ftd.GetVLANInterfaceByID(v.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if v3.MTU != 1400 {
+		t.Errorf("expecting MTU 1400, got %d\n", v3.MTU)
+	}
+
+	err = ftd.DeleteVLANInterface(v2)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ftd.GetVLANInterfaceByID(v.ID)
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+}
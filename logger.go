@@ -0,0 +1,27 @@
+package goftd
+
+import (
+	"github.com/golang/glog"
+)
+
+// Logger Receives the log messages of the library
+type Logger interface {
+	Errorf(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+}
+
+// glogLogger default logger, writes to glog
+type glogLogger struct{}
+
+func (glogLogger) Errorf(format string, args ...interface{}) {
+	glog.Errorf(format, args...)
+}
+
+func (glogLogger) Warningf(format string, args ...interface{}) {
+	glog.Warningf(format, args...)
+}
+
+func (glogLogger) Infof(format string, args ...interface{}) {
+	glog.Infof(format, args...)
+}
@@ -0,0 +1,299 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ManualNATRule NAT rule translating source and destination, also known as twice NAT.
+// Manual NAT rules are evaluated in order, see CreateManualNATRuleAt and MoveManualNATRule.
+type ManualNATRule struct {
+	ReferenceObject
+	Description               string           `json:"description,omitempty"`
+	SourceInterface           *ReferenceObject `json:"sourceInterface,omitempty"`
+	DestinationInterface      *ReferenceObject `json:"destinationInterface,omitempty"`
+	NATType                   string           `json:"natType,omitempty"`
+	OriginalSource            *ReferenceObject `json:"originalSource,omitempty"`
+	OriginalDestination       *ReferenceObject `json:"originalDestination,omitempty"`
+	OriginalSourcePort        *ReferenceObject `json:"originalSourcePort,omitempty"`
+	OriginalDestinationPort   *ReferenceObject `json:"originalDestinationPort,omitempty"`
+	TranslatedSource          *ReferenceObject `json:"translatedSource,omitempty"`
+	TranslatedDestination     *ReferenceObject `json:"translatedDestination,omitempty"`
+	TranslatedSourcePort      *ReferenceObject `json:"translatedSourcePort,omitempty"`
+	TranslatedDestinationPort *ReferenceObject `json:"translatedDestinationPort,omitempty"`
+	// InterfacePAT translates the source to the address of the destination interface
+	InterfacePAT                   bool   `json:"interfaceInTranslatedSource,omitempty"`
+	InterfaceInOriginalDestination bool   `json:"interfaceInOriginalDestination,omitempty"`
+	InterfaceIPv6                  bool   `json:"interfaceIPv6,omitempty"`
+	NetToNet                       bool   `json:"netToNet,omitempty"`
+	NoProxyARP                     bool   `json:"noProxyArp,omitempty"`
+	RouteLookup                    bool   `json:"routeLookup,omitempty"`
+	DNS                            bool   `json:"dns,omitempty"`
+	Unidirectional                 bool   `json:"unidirectional,omitempty"`
+	Enabled                        bool   `json:"enabled"`
+	Links                          *Links `json:"links,omitempty"`
+	parent                         string
+}
+
+// Reference Returns a reference object
+func (n *ManualNATRule) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      n.ID,
+		Name:    n.Name,
+		Version: n.Version,
+		Type:    n.Type,
+	}
+
+	return &r
+}
+
+// GetManualNATRules Get a list of manual NAT rules in order, a limit of 0 returns all of them
+func (f *FTD) GetManualNATRules(policy string, limit int) ([]*ManualNATRule, error) {
+	return f.GetManualNATRulesContext(context.Background(), policy, limit)
+}
+
+// GetManualNATRulesContext Same as GetManualNATRules, ctx cancels the requests
+func (f *FTD) GetManualNATRulesContext(ctx context.Context, policy string, limit int) ([]*ManualNATRule, error) {
+	var err error
+	var retval []*ManualNATRule
+
+	err = f.iterManualNATRules(ctx, policy, nil, limit, func(n *ManualNATRule) error {
+		retval = append(retval, n)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterManualNATRules Calls fn for every manual NAT rule of a policy in order, fetching one page at a time
+func (f *FTD) IterManualNATRules(ctx context.Context, policy string, fn func(*ManualNATRule) error) error {
+	return f.iterManualNATRules(ctx, policy, nil, 0, fn)
+}
+
+func (f *FTD) iterManualNATRules(ctx context.Context, policy string, query map[string]string, limit int, fn func(*ManualNATRule) error) error {
+	endpoint := fmt.Sprintf("%s/%s/manualnatrules", apiManualNATPoliciesEndpoint, policy)
+	return f.iterate(ctx, endpoint, query, limit, func(item json.RawMessage) error {
+		var n *ManualNATRule
+
+		err := json.Unmarshal(item, &n)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		n.parent = policy
+		return fn(n)
+	})
+}
+
+// GetManualNATRuleByID Get a manual NAT rule by ID
+func (f *FTD) GetManualNATRuleByID(policy, id string) (*ManualNATRule, error) {
+	return f.GetManualNATRuleByIDContext(context.Background(), policy, id)
+}
+
+// GetManualNATRuleByIDContext Same as GetManualNATRuleByID, ctx cancels the requests
+func (f *FTD) GetManualNATRuleByIDContext(ctx context.Context, policy, id string) (*ManualNATRule, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s/manualnatrules/%s", apiManualNATPoliciesEndpoint, policy, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *ManualNATRule
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	v.parent = policy
+	return v, nil
+}
+
+// GetManualNATRuleByName Get a manual NAT rule by name
+func (f *FTD) GetManualNATRuleByName(policy, name string) (*ManualNATRule, error) {
+	return f.GetManualNATRuleByNameContext(context.Background(), policy, name)
+}
+
+// GetManualNATRuleByNameContext Same as GetManualNATRuleByName, ctx cancels the requests
+func (f *FTD) GetManualNATRuleByNameContext(ctx context.Context, policy, name string) (*ManualNATRule, error) {
+	var retval *ManualNATRule
+
+	filter := make(map[string]string)
+	filter["filter"] = fmt.Sprintf("name:%s", name)
+
+	err := f.iterManualNATRules(ctx, policy, filter, 0, func(n *ManualNATRule) error {
+		if n.Name == name {
+			retval = n
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("manual NAT rule %s: %w", name, ErrNotFound)
+	}
+
+	return retval, nil
+}
+
+// CreateManualNATRule Create a new manual NAT rule at the end of the policy
+func (f *FTD) CreateManualNATRule(n *ManualNATRule, policy string) error {
+	return f.CreateManualNATRuleContext(context.Background(), n, policy)
+}
+
+// CreateManualNATRuleContext Same as CreateManualNATRule, ctx cancels the requests
+func (f *FTD) CreateManualNATRuleContext(ctx context.Context, n *ManualNATRule, policy string) error {
+	return f.createManualNATRule(ctx, n, policy, nil)
+}
+
+// CreateManualNATRuleAt Create a new manual NAT rule at position at of the policy, starting from 0
+func (f *FTD) CreateManualNATRuleAt(n *ManualNATRule, policy string, at int) error {
+	return f.CreateManualNATRuleAtContext(context.Background(), n, policy, at)
+}
+
+// CreateManualNATRuleAtContext Same as CreateManualNATRuleAt, ctx cancels the requests
+func (f *FTD) CreateManualNATRuleAtContext(ctx context.Context, n *ManualNATRule, policy string, at int) error {
+	query := make(map[string]string)
+	query["at"] = strconv.Itoa(at)
+
+	return f.createManualNATRule(ctx, n, policy, query)
+}
+
+func (f *FTD) createManualNATRule(ctx context.Context, n *ManualNATRule, policy string, query map[string]string) error {
+	var err error
+
+	n.Type = "manualnatrule"
+
+	r := requestParameters{
+		FTDRequest: n,
+		URIQuery:   query,
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/manualnatrules", apiManualNATPoliciesEndpoint, policy)
+	data, err := f.request(ctx, endpoint, apiPOST, &r)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	n.parent = policy
+
+	return nil
+}
+
+// UpdateManualNATRule Updates a manual NAT rule, its position is unchanged
+func (f *FTD) UpdateManualNATRule(n *ManualNATRule) error {
+	return f.UpdateManualNATRuleContext(context.Background(), n)
+}
+
+// UpdateManualNATRuleContext Same as UpdateManualNATRule, ctx cancels the requests
+func (f *FTD) UpdateManualNATRuleContext(ctx context.Context, n *ManualNATRule) error {
+	return f.updateManualNATRule(ctx, n, nil)
+}
+
+// MoveManualNATRule Moves a manual NAT rule to position at of its policy, starting from 0
+func (f *FTD) MoveManualNATRule(n *ManualNATRule, at int) error {
+	return f.MoveManualNATRuleContext(context.Background(), n, at)
+}
+
+// MoveManualNATRuleContext Same as MoveManualNATRule, ctx cancels the requests
+func (f *FTD) MoveManualNATRuleContext(ctx context.Context, n *ManualNATRule, at int) error {
+	query := make(map[string]string)
+	query["at"] = strconv.Itoa(at)
+
+	return f.updateManualNATRule(ctx, n, query)
+}
+
+func (f *FTD) updateManualNATRule(ctx context.Context, n *ManualNATRule, query map[string]string) error {
+	var err error
+
+	r := requestParameters{
+		FTDRequest: n,
+		URIQuery:   query,
+	}
+
+	if n.parent == "" {
+		err = errNoParent("manual NAT rule", n.ID, "policy", "GetManualNATRuleByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/manualnatrules/%s", apiManualNATPoliciesEndpoint, n.parent, n.ID)
+	data, err := f.request(ctx, endpoint, apiPUT, &r)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteManualNATRule Delete a manual NAT rule
+func (f *FTD) DeleteManualNATRule(n *ManualNATRule) error {
+	return f.DeleteManualNATRuleContext(context.Background(), n)
+}
+
+// DeleteManualNATRuleContext Same as DeleteManualNATRule, ctx cancels the requests
+func (f *FTD) DeleteManualNATRuleContext(ctx context.Context, n *ManualNATRule) error {
+	var err error
+
+	if n.parent == "" {
+		err = errNoParent("manual NAT rule", n.ID, "policy", "GetManualNATRuleByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/manualnatrules/%s", apiManualNATPoliciesEndpoint, n.parent, n.ID)
+	err = f.DeleteContext(ctx, endpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// NATPolicy Container of NAT rules, see ObjectNATPolicy, ManualNATPolicyBefore and ManualNATPolicyAfter
+type NATPolicy struct {
+	ReferenceObject
+	Rules []*ReferenceObject `json:"rules,omitempty"`
+	Links *Links             `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (p *NATPolicy) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      p.ID,
+		Name:    p.Name,
+		Version: p.Version,
+		Type:    p.Type,
+	}
+
+	return &r
+}
+
+// GetObjectNATPolicies Get a list of object NAT policies, a limit of 0 returns all of them
+func (f *FTD) GetObjectNATPolicies(limit int) ([]*NATPolicy, error) {
+	return f.GetObjectNATPoliciesContext(context.Background(), limit)
+}
+
+// GetObjectNATPoliciesContext Same as GetObjectNATPolicies, ctx cancels the requests
+func (f *FTD) GetObjectNATPoliciesContext(ctx context.Context, limit int) ([]*NATPolicy, error) {
+	return f.getNATPolicies(ctx, apiObjectNATPoliciesEndpoint, limit)
+}
+
+// GetObjectNATPolicyByName Get an object NAT policy by name, e.g. ObjectNATPolicy
+func (f *FTD) GetObjectNATPolicyByName(name string) (*NATPolicy, error) {
+	return f.GetObjectNATPolicyByNameContext(context.Background(), name)
+}
+
+// GetObjectNATPolicyByNameContext Same as GetObjectNATPolicyByName, ctx cancels the requests
+func (f *FTD) GetObjectNATPolicyByNameContext(ctx context.Context, name string) (*NATPolicy, error) {
+	return f.getNATPolicyByName(ctx, apiObjectNATPoliciesEndpoint, name)
+}
+
+// GetManualNATPolicies Get a list of manual NAT policies, a limit of 0 returns all of them
+func (f *FTD) GetManualNATPolicies(limit int) ([]*NATPolicy, error) {
+	return f.GetManualNATPoliciesContext(context.Background(), limit)
+}
+
+// GetManualNATPoliciesContext Same as GetManualNATPolicies, ctx cancels the requests
+func (f *FTD) GetManualNATPoliciesContext(ctx context.Context, limit int) ([]*NATPolicy, error) {
+	return f.getNATPolicies(ctx, apiManualNATPoliciesEndpoint, limit)
+}
+
+// GetManualNATPolicyByName Get a manual NAT policy by name, e.g. ManualNATPolicyBefore
+func (f *FTD) GetManualNATPolicyByName(name string) (*NATPolicy, error) {
+	return f.GetManualNATPolicyByNameContext(context.Background(), name)
+}
+
+// GetManualNATPolicyByNameContext Same as GetManualNATPolicyByName, ctx cancels the requests
+func (f *FTD) GetManualNATPolicyByNameContext(ctx context.Context, name string) (*NATPolicy, error) {
+	return f.getNATPolicyByName(ctx, apiManualNATPoliciesEndpoint, name)
+}
+
+func (f *FTD) iterNATPolicies(ctx context.Context, endpoint string, limit int, fn func(*NATPolicy) error) error {
+	return f.iterate(ctx, endpoint, nil, limit, func(item json.RawMessage) error {
+		var p *NATPolicy
+
+		err := json.Unmarshal(item, &p)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(p)
+	})
+}
+
+func (f *FTD) getNATPolicies(ctx context.Context, endpoint string, limit int) ([]*NATPolicy, error) {
+	var err error
+	var retval []*NATPolicy
+
+	err = f.iterNATPolicies(ctx, endpoint, limit, func(p *NATPolicy) error {
+		retval = append(retval, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+func (f *FTD) getNATPolicyByName(ctx context.Context, endpoint, name string) (*NATPolicy, error) {
+	var retval *NATPolicy
+
+	err := f.iterNATPolicies(ctx, endpoint, 0, func(p *NATPolicy) error {
+		if p.Name == name {
+			retval = p
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("NAT policy %s: %w", name, ErrNotFound)
+	}
+
+	return retval, nil
+}
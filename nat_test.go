@@ -0,0 +1,117 @@
+package goftd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCreateManualNATRuleAt(t *testing.T) {
+	var at, path string
+	h := func(w http.ResponseWriter, r *http.Request) {
+		at = r.URL.Query().Get("at")
+		path = r.URL.Path
+		w.Write([]byte(`{"id":"rule1","name":"testNat001","type":"manualnatrule"}`))
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+
+	n := new(ManualNATRule)
+	n.Name = "testNat001"
+
+	err := ftd.CreateManualNATRuleAt(n, "policy1", 2)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if at != "2" {
+		t.Errorf("expecting at=2, got %q\n", at)
+	}
+
+	if path != "/"+apiBasePath+"policy/manualnatpolicies/policy1/manualnatrules" {
+		t.Errorf("unexpected path %s\n", path)
+	}
+
+	if n.ID != "rule1" || n.parent != "policy1" {
+		t.Errorf("rule is not populated correctly: %+v\n", n)
+	}
+}
+
+func TestManualNATRule(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	p, err := ftd.GetManualNATPolicyByName(ManualNATPolicyBefore)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	src := new(NetworkObject)
+	src.Name = "testNatSrc001"
+	src.SubType = "NETWORK"
+	src.Value = "10.10.10.0/24"
+
+	err = ftd.CreateNetworkObject(src, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	dst := new(NetworkObject)
+	dst.Name = "testNatDst001"
+	dst.SubType = "HOST"
+	dst.Value = "192.0.2.10"
+
+	err = ftd.CreateNetworkObject(dst, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n := new(ManualNATRule)
+	n.Name = "testNat001"
+	n.NATType = NATTypeStatic
+	n.OriginalSource = src.Reference()
+	n.TranslatedSource = dst.Reference()
+	n.NoProxyARP = true
+	n.Enabled = true
+
+	err = ftd.CreateManualNATRuleAt(n, p.ID, 0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if n.ID == "" || n.Version == "" {
+		t.Errorf("ID of value is not populated correctly\n")
+	}
+
+	n2, err := ftd.GetManualNATRuleByName(p.ID, n.Name)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if n2.ID != n.ID {
+		t.Errorf("expected ID %s, got %s\n", n.ID, n2.ID)
+	}
+
+	err = ftd.DeleteManualNATRule(n)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	err = ftd.DeleteNetworkObject(src)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	err = ftd.DeleteNetworkObject(dst)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+}
@@ -1,11 +1,9 @@
 package goftd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-
-	"github.com/golang/glog"
 )
 
 // NetworkObject An object represents the network (Note: The field level constraints listed here might not cover all the constraints on the field. Additional constraints might exist.)
@@ -14,6 +12,7 @@ type NetworkObject struct {
 	Description     string `json:"description,omitempty"`
 	SubType         string `json:"subType"`
 	Value           string `json:"value"`
+	DNSResolution   string `json:"dnsResolution,omitempty"`
 	IsSystemDefined bool   `json:"isSystemDefined,omitempty"`
 	Links           *Links `json:"links,omitempty"`
 }
@@ -30,40 +29,59 @@ func (n *NetworkObject) Reference() *ReferenceObject {
 	return &r
 }
 
-// GetNetworkObjects Get a list of network objects
+// GetNetworkObjects Get a list of network objects, a limit of 0 returns all of them
 func (f *FTD) GetNetworkObjects(limit int) ([]*NetworkObject, error) {
-	var err error
+	return f.GetNetworkObjectsContext(context.Background(), limit)
+}
 
-	filter := make(map[string]string)
-	filter["limit"] = strconv.Itoa(limit)
+// GetNetworkObjectsContext Same as GetNetworkObjects, ctx cancels the requests
+func (f *FTD) GetNetworkObjectsContext(ctx context.Context, limit int) ([]*NetworkObject, error) {
+	var err error
+	var retval []*NetworkObject
 
-	endpoint := apiNetworksEndpoint
-	data, err := f.Get(endpoint, filter)
+	err = f.iterNetworkObjects(ctx, nil, limit, func(n *NetworkObject) error {
+		retval = append(retval, n)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var v struct {
-		Items []*NetworkObject `json:"items"`
-	}
+	return retval, nil
+}
 
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+// IterNetworkObjects Calls fn for every network object, fetching one page at a time
+func (f *FTD) IterNetworkObjects(ctx context.Context, fn func(*NetworkObject) error) error {
+	return f.iterNetworkObjects(ctx, nil, 0, fn)
+}
+
+func (f *FTD) iterNetworkObjects(ctx context.Context, query map[string]string, limit int, fn func(*NetworkObject) error) error {
+	return f.iterate(ctx, apiNetworksEndpoint, query, limit, func(item json.RawMessage) error {
+		var n *NetworkObject
+
+		err := json.Unmarshal(item, &n)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	return v.Items, nil
+		return fn(n)
+	})
 }
 
 // GetNetworkObjectByID Get a network object by ID
 func (f *FTD) GetNetworkObjectByID(id string) (*NetworkObject, error) {
+	return f.GetNetworkObjectByIDContext(context.Background(), id)
+}
+
+// GetNetworkObjectByIDContext Same as GetNetworkObjectByID, ctx cancels the requests
+func (f *FTD) GetNetworkObjectByIDContext(ctx context.Context, id string) (*NetworkObject, error) {
 	var err error
 
 	endpoint := fmt.Sprintf("%s/%s", apiNetworksEndpoint, id)
-	data, err := f.Get(endpoint, nil)
+	data, err := f.GetContext(ctx, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +91,7 @@ func (f *FTD) GetNetworkObjectByID(id string) (*NetworkObject, error) {
 	err = json.Unmarshal(data, &v)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
@@ -81,74 +99,59 @@ func (f *FTD) GetNetworkObjectByID(id string) (*NetworkObject, error) {
 	return v, nil
 }
 
-func (f *FTD) getNetworkObjectBy(filterString string, limit int) ([]*NetworkObject, error) {
+func (f *FTD) getNetworkObjectBy(ctx context.Context, filterString string, limit int) ([]*NetworkObject, error) {
 	var err error
+	var retval []*NetworkObject
 
 	filter := make(map[string]string)
 	filter["filter"] = filterString
-	filter["limit"] = strconv.Itoa(limit)
-
-	endpoint := apiNetworksEndpoint
-	data, err := f.Get(endpoint, filter)
-	if err != nil {
-		return nil, err
-	}
-
-	var v struct {
-		Items []*NetworkObject `json:"items"`
-	}
 
-	err = json.Unmarshal(data, &v)
+	err = f.iterNetworkObjects(ctx, filter, limit, func(n *NetworkObject) error {
+		retval = append(retval, n)
+		return nil
+	})
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
 		return nil, err
 	}
 
-	return v.Items, nil
+	return retval, nil
 }
 
 // CreateNetworkObject Create a new network object
 func (f *FTD) CreateNetworkObject(n *NetworkObject, duplicateAction int) error {
+	return f.CreateNetworkObjectContext(context.Background(), n, duplicateAction)
+}
+
+// CreateNetworkObjectContext Same as CreateNetworkObject, ctx cancels the requests
+func (f *FTD) CreateNetworkObjectContext(ctx context.Context, n *NetworkObject, duplicateAction int) error {
 	var err error
 
+	err = n.Validate()
+	if err != nil {
+		return err
+	}
+
 	n.Type = "networkobject"
-	_, err = f.Post(apiNetworksEndpoint, n)
+	_, err = f.PostContext(ctx, apiNetworksEndpoint, n)
 	if err != nil {
-		ftdErr := err.(*FTDError)
-		//spew.Dump(ftdErr)
-		if len(ftdErr.Message) > 0 && (ftdErr.Message[0].Code == "duplicateName" || ftdErr.Message[0].Code == "newInstanceWithDuplicateId") {
+		if IsDuplicate(err) {
 			if f.debug {
-				glog.Warningf("This is a duplicate\n")
+				f.logger.Warningf("This is a duplicate\n")
 			}
 			if duplicateAction == DuplicateActionError {
 				return err
 			}
 		} else {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
 	}
 
-	query := fmt.Sprintf("name:%s", n.Name)
-	obj, err := f.getNetworkObjectBy(query, 0)
+	o := new(NetworkObject)
+	err = f.findCreated(ctx, apiNetworksEndpoint, n.Name, o)
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
-		return err
-	}
-
-	var o *NetworkObject
-	if len(obj) == 1 {
-		o = obj[0]
-	} else {
-		if f.debug {
-			glog.Errorf("Error: length of object is not 1\n")
-		}
 		return err
 	}
 
@@ -156,11 +159,12 @@ func (f *FTD) CreateNetworkObject(n *NetworkObject, duplicateAction int) error {
 	case DuplicateActionReplace:
 		o.Value = n.Value
 		o.SubType = n.SubType
+		o.DNSResolution = n.DNSResolution
 
-		err = f.UpdateNetworkObject(o)
+		err = f.UpdateNetworkObjectContext(ctx, o)
 		if err != nil {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
@@ -172,13 +176,18 @@ func (f *FTD) CreateNetworkObject(n *NetworkObject, duplicateAction int) error {
 
 // CreateNetworkObjectsFromIPs Create Network objects from an array of IP
 func (f *FTD) CreateNetworkObjectsFromIPs(ips []string) ([]*NetworkObject, error) {
+	return f.CreateNetworkObjectsFromIPsContext(context.Background(), ips)
+}
+
+// CreateNetworkObjectsFromIPsContext Same as CreateNetworkObjectsFromIPs, ctx cancels the requests
+func (f *FTD) CreateNetworkObjectsFromIPsContext(ctx context.Context, ips []string) ([]*NetworkObject, error) {
 	var err error
 	var retval []*NetworkObject
 
-	os, err := f.GetNetworkObjects(0)
+	os, err := f.GetNetworkObjectsContext(ctx, 0)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
@@ -187,7 +196,7 @@ func (f *FTD) CreateNetworkObjectsFromIPs(ips []string) ([]*NetworkObject, error
 
 	for i := range ips {
 		for o := range os {
-			if ips[i] == os[o].Value && os[o].SubType == "HOST" {
+			if ips[i] == os[o].Value && os[o].SubType == NetworkObjectSubTypeHost {
 				retval = append(retval, os[o])
 				found[ips[i]] = true
 				break
@@ -201,12 +210,12 @@ func (f *FTD) CreateNetworkObjectsFromIPs(ips []string) ([]*NetworkObject, error
 			n := new(NetworkObject)
 			n.Name = ips[i]
 			n.Value = ips[i]
-			n.SubType = "HOST"
+			n.SubType = NetworkObjectSubTypeHost
 
-			err = f.CreateNetworkObject(n, DuplicateActionDoNothing)
+			err = f.CreateNetworkObjectContext(ctx, n, DuplicateActionDoNothing)
 			if err != nil {
 				if f.debug {
-					glog.Errorf("Error: %s\n", err)
+					f.logger.Errorf("Error: %s\n", err)
 				}
 				return nil, err
 			}
@@ -217,14 +226,119 @@ func (f *FTD) CreateNetworkObjectsFromIPs(ips []string) ([]*NetworkObject, error
 	return retval, nil
 }
 
+// CreateNetworkObjectsFromCIDRs Create Network objects from an array of CIDR, reusing existing NETWORK objects
+func (f *FTD) CreateNetworkObjectsFromCIDRs(cidrs []string) ([]*NetworkObject, error) {
+	return f.CreateNetworkObjectsFromCIDRsContext(context.Background(), cidrs)
+}
+
+// CreateNetworkObjectsFromCIDRsContext Same as CreateNetworkObjectsFromCIDRs, ctx cancels the requests
+func (f *FTD) CreateNetworkObjectsFromCIDRsContext(ctx context.Context, cidrs []string) ([]*NetworkObject, error) {
+	var objs []*NetworkObject
+
+	for i := range cidrs {
+		n, err := parseNetwork(cidrs[i])
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, n)
+	}
+
+	retval, _, err := f.createNetworkObjects(ctx, objs)
+	return retval, err
+}
+
+// CreateNetworkObjectsFromStrings Create Network objects from an array of IP, CIDR, range (a-b) or hostname,
+// the subtype of each object is guessed from its value. Existing objects with the same subtype and value are reused.
+func (f *FTD) CreateNetworkObjectsFromStrings(values []string) ([]*NetworkObject, error) {
+	return f.CreateNetworkObjectsFromStringsContext(context.Background(), values)
+}
+
+// CreateNetworkObjectsFromStringsContext Same as CreateNetworkObjectsFromStrings, ctx cancels the requests
+func (f *FTD) CreateNetworkObjectsFromStringsContext(ctx context.Context, values []string) ([]*NetworkObject, error) {
+	var objs []*NetworkObject
+
+	for i := range values {
+		n, err := ParseNetworkObject(values[i])
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, n)
+	}
+
+	retval, _, err := f.createNetworkObjects(ctx, objs)
+	return retval, err
+}
+
+// createNetworkObjects Creates objs in order, reusing the existing objects with the same subtype and value,
+// or with the same name. created holds the objects actually created, even on error.
+func (f *FTD) createNetworkObjects(ctx context.Context, objs []*NetworkObject) (retval, created []*NetworkObject, err error) {
+	os, err := f.GetNetworkObjectsContext(ctx, 0)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, nil, err
+	}
+
+	for i := range objs {
+		var n *NetworkObject
+
+		for o := range os {
+			if objs[i].Value == os[o].Value && objs[i].SubType == os[o].SubType {
+				n = os[o]
+				break
+			}
+		}
+
+		if n == nil {
+			n = objs[i]
+
+			err = f.CreateNetworkObjectContext(ctx, n, DuplicateActionError)
+			if IsDuplicate(err) {
+				n = new(NetworkObject)
+				err = f.findByName(ctx, apiNetworksEndpoint, objs[i].Name, n)
+			} else if err == nil {
+				created = append(created, n)
+			}
+			if err != nil {
+				if f.debug {
+					f.logger.Errorf("Error: %s\n", err)
+				}
+				return nil, created, err
+			}
+			os = append(os, n)
+		}
+
+		retval = append(retval, n)
+	}
+
+	return retval, created, nil
+}
+
+// deleteNetworkObjects Deletes objs, created by a helper that failed afterwards. The errors are only logged,
+// the one of the helper is returned.
+func (f *FTD) deleteNetworkObjects(ctx context.Context, objs []*NetworkObject) {
+	for i := len(objs) - 1; i >= 0; i-- {
+		err := f.DeleteNetworkObjectContext(ctx, objs[i])
+		if err != nil && f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+	}
+}
+
 // DeleteNetworkObject Delete a network object
 func (f *FTD) DeleteNetworkObject(n *NetworkObject) error {
+	return f.DeleteNetworkObjectContext(context.Background(), n)
+}
+
+// DeleteNetworkObjectContext Same as DeleteNetworkObject, ctx cancels the requests
+func (f *FTD) DeleteNetworkObjectContext(ctx context.Context, n *NetworkObject) error {
 	var err error
 
-	err = f.Delete(fmt.Sprintf("%s/%s", apiNetworksEndpoint, n.ID))
+	err = f.DeleteContext(ctx, fmt.Sprintf("%s/%s", apiNetworksEndpoint, n.ID))
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -234,12 +348,17 @@ func (f *FTD) DeleteNetworkObject(n *NetworkObject) error {
 
 // DeleteNetworkObjectByID Delete a network object
 func (f *FTD) DeleteNetworkObjectByID(id string) error {
+	return f.DeleteNetworkObjectByIDContext(context.Background(), id)
+}
+
+// DeleteNetworkObjectByIDContext Same as DeleteNetworkObjectByID, ctx cancels the requests
+func (f *FTD) DeleteNetworkObjectByIDContext(ctx context.Context, id string) error {
 	var err error
 
-	err = f.Delete(fmt.Sprintf("%s/%s", apiNetworksEndpoint, id))
+	err = f.DeleteContext(ctx, fmt.Sprintf("%s/%s", apiNetworksEndpoint, id))
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -249,13 +368,23 @@ func (f *FTD) DeleteNetworkObjectByID(id string) error {
 
 // UpdateNetworkObject Updates a network object
 func (f *FTD) UpdateNetworkObject(n *NetworkObject) error {
+	return f.UpdateNetworkObjectContext(context.Background(), n)
+}
+
+// UpdateNetworkObjectContext Same as UpdateNetworkObject, ctx cancels the requests
+func (f *FTD) UpdateNetworkObjectContext(ctx context.Context, n *NetworkObject) error {
 	var err error
 
+	err = n.Validate()
+	if err != nil {
+		return err
+	}
+
 	endpoint := fmt.Sprintf("%s/%s", apiNetworksEndpoint, n.ID)
-	data, err := f.Put(endpoint, n)
+	data, err := f.PutContext(ctx, endpoint, n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -263,7 +392,7 @@ func (f *FTD) UpdateNetworkObject(n *NetworkObject) error {
 	err = json.Unmarshal(data, &n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
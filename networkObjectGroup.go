@@ -1,11 +1,9 @@
 package goftd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-
-	"github.com/golang/glog"
 )
 
 // NetworkObjectGroup Network Object Group
@@ -17,6 +15,11 @@ type NetworkObjectGroup struct {
 	Links           *Links             `json:"links,omitempty"`
 }
 
+// NetworkMember A member of a network object group, a *NetworkObject or a nested *NetworkObjectGroup
+type NetworkMember interface {
+	Reference() *ReferenceObject
+}
+
 // Reference Returns a reference object
 func (g *NetworkObjectGroup) Reference() *ReferenceObject {
 	r := ReferenceObject{
@@ -29,100 +32,78 @@ func (g *NetworkObjectGroup) Reference() *ReferenceObject {
 	return &r
 }
 
-// GetNetworkObjectGroups Get a list of network objects
+// GetNetworkObjectGroups Get a list of network object groups, a limit of 0 returns all of them
 func (f *FTD) GetNetworkObjectGroups(limit int) ([]*NetworkObjectGroup, error) {
-	var err error
-
-	filter := make(map[string]string)
-	filter["limit"] = strconv.Itoa(limit)
-
-	data, err := f.Get("object/networkgroups", filter)
-	if err != nil {
-		return nil, err
-	}
+	return f.GetNetworkObjectGroupsContext(context.Background(), limit)
+}
 
-	var v struct {
-		Items []*NetworkObjectGroup `json:"items"`
-	}
+// GetNetworkObjectGroupsContext Same as GetNetworkObjectGroups, ctx cancels the requests
+func (f *FTD) GetNetworkObjectGroupsContext(ctx context.Context, limit int) ([]*NetworkObjectGroup, error) {
+	var err error
+	var retval []*NetworkObjectGroup
 
-	err = json.Unmarshal(data, &v)
+	err = f.iterNetworkObjectGroups(ctx, nil, limit, func(g *NetworkObjectGroup) error {
+		retval = append(retval, g)
+		return nil
+	})
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
 		return nil, err
 	}
 
-	return v.Items, nil
+	return retval, nil
 }
 
-func (f *FTD) getNetworkObjectGroupBy(filterString string) ([]*NetworkObjectGroup, error) {
-	var err error
-
-	filter := make(map[string]string)
-	filter["filter"] = filterString
-
-	endpoint := "object/networkgroups"
-	data, err := f.Get(endpoint, filter)
-	if err != nil {
-		return nil, err
-	}
+// IterNetworkObjectGroups Calls fn for every network object group, fetching one page at a time
+func (f *FTD) IterNetworkObjectGroups(ctx context.Context, fn func(*NetworkObjectGroup) error) error {
+	return f.iterNetworkObjectGroups(ctx, nil, 0, fn)
+}
 
-	var v struct {
-		Items []*NetworkObjectGroup `json:"items"`
-	}
+func (f *FTD) iterNetworkObjectGroups(ctx context.Context, query map[string]string, limit int, fn func(*NetworkObjectGroup) error) error {
+	return f.iterate(ctx, apiNetworkGroupsEndpoint, query, limit, func(item json.RawMessage) error {
+		var g *NetworkObjectGroup
 
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+		err := json.Unmarshal(item, &g)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	return v.Items, nil
+		return fn(g)
+	})
 }
 
 // CreateNetworkObjectGroup Create a new network object
 func (f *FTD) CreateNetworkObjectGroup(n *NetworkObjectGroup, duplicateAction int) error {
+	return f.CreateNetworkObjectGroupContext(context.Background(), n, duplicateAction)
+}
+
+// CreateNetworkObjectGroupContext Same as CreateNetworkObjectGroup, ctx cancels the requests
+func (f *FTD) CreateNetworkObjectGroupContext(ctx context.Context, n *NetworkObjectGroup, duplicateAction int) error {
 	var err error
 
 	n.Type = "networkobjectgroup"
-	_, err = f.Post("object/networkgroups", n)
+	_, err = f.PostContext(ctx, apiNetworkGroupsEndpoint, n)
 	if err != nil {
-		ftdErr := err.(*FTDError)
-		//spew.Dump(ftdErr)
-		if len(ftdErr.Message) > 0 && (ftdErr.Message[0].Code == "duplicateName" || ftdErr.Message[0].Code == "newInstanceWithDuplicateId") {
+		if IsDuplicate(err) {
 			if f.debug {
-				glog.Warningf("This is a duplicate\n")
+				f.logger.Warningf("This is a duplicate\n")
 			}
 			if duplicateAction == DuplicateActionError {
 				return err
 			}
 		} else {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
 	}
 
-	query := fmt.Sprintf("name:%s", n.Name)
-	obj, err := f.getNetworkObjectGroupBy(query)
+	o := new(NetworkObjectGroup)
+	err = f.findCreated(ctx, apiNetworkGroupsEndpoint, n.Name, o)
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
-		return err
-	}
-
-	var o *NetworkObjectGroup
-	if len(obj) == 1 {
-		o = obj[0]
-	} else {
-		if f.debug {
-			glog.Errorf("Error: length of object is not 1\n")
-		}
 		return err
 	}
 
@@ -130,10 +111,10 @@ func (f *FTD) CreateNetworkObjectGroup(n *NetworkObjectGroup, duplicateAction in
 	case DuplicateActionReplace:
 		o.Objects = n.Objects
 
-		err = f.UpdateNetworkObjectGroup(o)
+		err = f.UpdateNetworkObjectGroupContext(ctx, o)
 		if err != nil {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
@@ -145,13 +126,18 @@ func (f *FTD) CreateNetworkObjectGroup(n *NetworkObjectGroup, duplicateAction in
 
 // DeleteNetworkObjectGroup Delete a network object
 func (f *FTD) DeleteNetworkObjectGroup(n *NetworkObjectGroup) error {
+	return f.DeleteNetworkObjectGroupContext(context.Background(), n)
+}
+
+// DeleteNetworkObjectGroupContext Same as DeleteNetworkObjectGroup, ctx cancels the requests
+func (f *FTD) DeleteNetworkObjectGroupContext(ctx context.Context, n *NetworkObjectGroup) error {
 	var err error
 
-	endpoint := fmt.Sprintf("object/networkgroups/%s", n.ID)
-	err = f.Delete(endpoint)
+	endpoint := fmt.Sprintf("%s/%s", apiNetworkGroupsEndpoint, n.ID)
+	err = f.DeleteContext(ctx, endpoint)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -161,13 +147,18 @@ func (f *FTD) DeleteNetworkObjectGroup(n *NetworkObjectGroup) error {
 
 // UpdateNetworkObjectGroup Updates a network object group
 func (f *FTD) UpdateNetworkObjectGroup(n *NetworkObjectGroup) error {
+	return f.UpdateNetworkObjectGroupContext(context.Background(), n)
+}
+
+// UpdateNetworkObjectGroupContext Same as UpdateNetworkObjectGroup, ctx cancels the requests
+func (f *FTD) UpdateNetworkObjectGroupContext(ctx context.Context, n *NetworkObjectGroup) error {
 	var err error
 
-	endpoint := fmt.Sprintf("object/networkgroups/%s", n.ID)
-	data, err := f.Put(endpoint, n)
+	endpoint := fmt.Sprintf("%s/%s", apiNetworkGroupsEndpoint, n.ID)
+	data, err := f.PutContext(ctx, endpoint, n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -175,7 +166,7 @@ func (f *FTD) UpdateNetworkObjectGroup(n *NetworkObjectGroup) error {
 	err = json.Unmarshal(data, &n)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -183,24 +174,49 @@ func (f *FTD) UpdateNetworkObjectGroup(n *NetworkObjectGroup) error {
 	return nil
 }
 
-// AddToNetworkObjectGroup Add a Network to an Object Group
-func (f *FTD) AddToNetworkObjectGroup(g *NetworkObjectGroup, n *NetworkObject) error {
+// AddToNetworkObjectGroup Add a Network or a nested Object Group to an Object Group, nesting cycles are refused with ErrCycle
+func (f *FTD) AddToNetworkObjectGroup(g *NetworkObjectGroup, n NetworkMember) error {
+	return f.AddToNetworkObjectGroupContext(context.Background(), g, n)
+}
+
+// AddToNetworkObjectGroupContext Same as AddToNetworkObjectGroup, ctx cancels the requests
+func (f *FTD) AddToNetworkObjectGroupContext(ctx context.Context, g *NetworkObjectGroup, n NetworkMember) error {
 	var err error
+
+	r := n.Reference()
 	for k := range g.Objects {
-		if g.Objects[k].ID == n.ID {
+		if g.Objects[k].ID == r.ID {
 			if f.debug {
-				glog.Errorf("object already in object group\n")
-				return fmt.Errorf("object already in object group")
+				f.logger.Errorf("object already in object group\n")
 			}
+			return fmt.Errorf("object already in object group")
 		}
 	}
 
-	g.Objects = append(g.Objects, n.Reference())
+	if r.Type == TypeNetworkObjectGroup {
+		nested, err := newNetworkGroupResolver(ctx, f).contains(r.ID, g.ID)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
 
-	err = f.UpdateNetworkObjectGroup(g)
+		if nested || r.ID == g.ID {
+			err = fmt.Errorf("adding %s to %s: %w", r.Name, g.Name, ErrCycle)
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	g.Objects = append(g.Objects, r)
+
+	err = f.UpdateNetworkObjectGroupContext(ctx, g)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -208,20 +224,27 @@ func (f *FTD) AddToNetworkObjectGroup(g *NetworkObjectGroup, n *NetworkObject) e
 	return nil
 }
 
-// DeleteFromNetworkObjectGroup Deletes a Network to an Object Group
-func (f *FTD) DeleteFromNetworkObjectGroup(g *NetworkObjectGroup, n *NetworkObject) error {
+// DeleteFromNetworkObjectGroup Deletes a Network or a nested Object Group from an Object Group
+func (f *FTD) DeleteFromNetworkObjectGroup(g *NetworkObjectGroup, n NetworkMember) error {
+	return f.DeleteFromNetworkObjectGroupContext(context.Background(), g, n)
+}
+
+// DeleteFromNetworkObjectGroupContext Same as DeleteFromNetworkObjectGroup, ctx cancels the requests
+func (f *FTD) DeleteFromNetworkObjectGroupContext(ctx context.Context, g *NetworkObjectGroup, n NetworkMember) error {
 	var err error
+
+	r := n.Reference()
 	for k := range g.Objects {
-		if g.Objects[k].ID == n.ID {
+		if g.Objects[k].ID == r.ID {
 			g.Objects = append(g.Objects[:k], g.Objects[k+1:]...)
 			break
 		}
 	}
 
-	err = f.UpdateNetworkObjectGroup(g)
+	err = f.UpdateNetworkObjectGroupContext(ctx, g)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -231,12 +254,17 @@ func (f *FTD) DeleteFromNetworkObjectGroup(g *NetworkObjectGroup, n *NetworkObje
 
 // CreateNetworkObjectGroupFromIPs Create an object group from an array of ip address. Network objects = ip.
 func (f *FTD) CreateNetworkObjectGroupFromIPs(name string, ips []string, duplicateAction int) (*NetworkObjectGroup, error) {
+	return f.CreateNetworkObjectGroupFromIPsContext(context.Background(), name, ips, duplicateAction)
+}
+
+// CreateNetworkObjectGroupFromIPsContext Same as CreateNetworkObjectGroupFromIPs, ctx cancels the requests
+func (f *FTD) CreateNetworkObjectGroupFromIPsContext(ctx context.Context, name string, ips []string, duplicateAction int) (*NetworkObjectGroup, error) {
 	var err error
 
-	ns, err := f.CreateNetworkObjectsFromIPs(ips)
+	ns, err := f.CreateNetworkObjectsFromIPsContext(ctx, ips)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
@@ -248,10 +276,10 @@ func (f *FTD) CreateNetworkObjectGroupFromIPs(name string, ips []string, duplica
 		g.Objects = append(g.Objects, ns[i].Reference())
 	}
 
-	err = f.CreateNetworkObjectGroup(g, duplicateAction)
+	err = f.CreateNetworkObjectGroupContext(ctx, g, duplicateAction)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
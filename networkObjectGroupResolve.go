@@ -0,0 +1,198 @@
+package goftd
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// ResolvedNetworkObjectGroup The addresses a network object group covers once its nested groups are flattened
+type ResolvedNetworkObjectGroup struct {
+	// Objects network objects reached through the group and its nested groups, once each
+	Objects []*NetworkObject
+	// Networks CIDRs of the HOST and NETWORK objects, a host is a /32 or a /128
+	Networks []string
+	// Ranges first-last of the RANGE objects
+	Ranges []string
+	// FQDNs hostnames of the FQDN objects
+	FQDNs []string
+}
+
+// add Adds the value of n to the list of its sub type, once
+func (r *ResolvedNetworkObjectGroup) add(n *NetworkObject) {
+	r.Objects = append(r.Objects, n)
+
+	var list *[]string
+	value := n.Value
+
+	switch n.SubType {
+	case NetworkObjectSubTypeHost:
+		list = &r.Networks
+		if ip := net.ParseIP(value); ip != nil {
+			if ip.To4() != nil {
+				value = fmt.Sprintf("%s/32", ip)
+			} else {
+				value = fmt.Sprintf("%s/128", ip)
+			}
+		}
+	case NetworkObjectSubTypeNetwork:
+		list = &r.Networks
+	case NetworkObjectSubTypeRange:
+		list = &r.Ranges
+	case NetworkObjectSubTypeFQDN:
+		list = &r.FQDNs
+	default:
+		return
+	}
+
+	for _, v := range *list {
+		if v == value {
+			return
+		}
+	}
+	*list = append(*list, value)
+}
+
+// ResolveNetworkObjectGroup Flattens a network object group and its nested groups into the networks, ranges and FQDNs it covers
+func (f *FTD) ResolveNetworkObjectGroup(g *NetworkObjectGroup) (*ResolvedNetworkObjectGroup, error) {
+	return f.ResolveNetworkObjectGroupContext(context.Background(), g)
+}
+
+// ResolveNetworkObjectGroupContext Same as ResolveNetworkObjectGroup, ctx cancels the requests
+func (f *FTD) ResolveNetworkObjectGroupContext(ctx context.Context, g *NetworkObjectGroup) (*ResolvedNetworkObjectGroup, error) {
+	retval := new(ResolvedNetworkObjectGroup)
+	seen := make(map[string]bool)
+
+	err := newNetworkGroupResolver(ctx, f).walk(g, make(map[string]bool), func(n *NetworkObject) {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			retval.add(n)
+		}
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// networkGroupResolver Fetches the members of network object groups, each of them once
+type networkGroupResolver struct {
+	ctx     context.Context
+	f       *FTD
+	groups  map[string]*NetworkObjectGroup
+	objects map[string]*NetworkObject
+}
+
+func newNetworkGroupResolver(ctx context.Context, f *FTD) *networkGroupResolver {
+	return &networkGroupResolver{
+		ctx:     ctx,
+		f:       f,
+		groups:  make(map[string]*NetworkObjectGroup),
+		objects: make(map[string]*NetworkObject),
+	}
+}
+
+// group Returns the network object group id
+func (r *networkGroupResolver) group(id string) (*NetworkObjectGroup, error) {
+	if g, ok := r.groups[id]; ok {
+		return g, nil
+	}
+
+	g := new(NetworkObjectGroup)
+	err := r.f.getByID(r.ctx, apiNetworkGroupsEndpoint, id, g)
+	if err != nil {
+		return nil, err
+	}
+	r.groups[id] = g
+
+	return g, nil
+}
+
+// object Returns the network object id
+func (r *networkGroupResolver) object(id string) (*NetworkObject, error) {
+	if n, ok := r.objects[id]; ok {
+		return n, nil
+	}
+
+	n, err := r.f.GetNetworkObjectByIDContext(r.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.objects[id] = n
+
+	return n, nil
+}
+
+// walk Calls fn for every network object of g and of its nested groups, path holds the groups being walked
+func (r *networkGroupResolver) walk(g *NetworkObjectGroup, path map[string]bool, fn func(*NetworkObject)) error {
+	path[g.ID] = true
+	defer delete(path, g.ID)
+
+	for _, ref := range g.Objects {
+		if ref.Type != TypeNetworkObjectGroup {
+			n, err := r.object(ref.ID)
+			if err != nil {
+				return err
+			}
+			fn(n)
+			continue
+		}
+
+		if path[ref.ID] {
+			return fmt.Errorf("network object group %s: %w", ref.Name, ErrCycle)
+		}
+
+		nested, err := r.group(ref.ID)
+		if err != nil {
+			return err
+		}
+
+		err = r.walk(nested, path, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// contains Returns true if the group id nests the group target, at any depth
+func (r *networkGroupResolver) contains(id, target string) (bool, error) {
+	seen := make(map[string]bool)
+
+	var visit func(id string) (bool, error)
+	visit = func(id string) (bool, error) {
+		if seen[id] {
+			return false, nil
+		}
+		seen[id] = true
+
+		g, err := r.group(id)
+		if err != nil {
+			return false, err
+		}
+
+		for _, ref := range g.Objects {
+			if ref.Type != TypeNetworkObjectGroup {
+				continue
+			}
+
+			if ref.ID == target {
+				return true, nil
+			}
+
+			found, err := visit(ref.ID)
+			if found || err != nil {
+				return found, err
+			}
+		}
+
+		return false, nil
+	}
+
+	return visit(id)
+}
@@ -1,6 +1,7 @@
 package goftd
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/golang/glog"
@@ -272,3 +273,118 @@ func TestCreateNetworkObjectGroupFromIPs(t *testing.T) {
 	}
 
 }
+
+func TestNestedNetworkObjectGroup(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	h, _ := NewHostObject("testObj001", "192.0.2.1")
+	r, _ := NewRangeObject("testRange001", "192.0.2.10", "192.0.2.20")
+	d, _ := NewFQDNObject("testFQDN001", "www.example.com", "")
+
+	for _, n := range []*NetworkObject{h, r, d} {
+		err = ftd.CreateNetworkObject(n, DuplicateActionReplace)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+		defer ftd.DeleteNetworkObject(n)
+	}
+
+	inner := new(NetworkObjectGroup)
+	inner.Name = "testObjGroup002"
+	inner.Objects = append(inner.Objects, h.Reference(), r.Reference(), d.Reference())
+
+	err = ftd.CreateNetworkObjectGroup(inner, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObjectGroup(inner)
+
+	outer := new(NetworkObjectGroup)
+	outer.Name = "testObjGroup001"
+	outer.Objects = append(outer.Objects, h.Reference())
+
+	err = ftd.CreateNetworkObjectGroup(outer, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObjectGroup(outer)
+
+	err = ftd.AddToNetworkObjectGroup(outer, inner)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	res, err := ftd.ResolveNetworkObjectGroup(outer)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(res.Objects) != 3 || len(res.Networks) != 1 || res.Networks[0] != "192.0.2.1/32" {
+		t.Errorf("expecting 3 objects and 192.0.2.1/32, got %d and %v\n", len(res.Objects), res.Networks)
+	}
+
+	if len(res.Ranges) != 1 || res.Ranges[0] != "192.0.2.10-192.0.2.20" || len(res.FQDNs) != 1 || res.FQDNs[0] != "www.example.com" {
+		t.Errorf("expecting the range and the FQDN, got %v and %v\n", res.Ranges, res.FQDNs)
+	}
+
+	for _, g := range []*NetworkObjectGroup{outer, inner} {
+		err = ftd.AddToNetworkObjectGroup(inner, g)
+		if !errors.Is(err, ErrCycle) {
+			t.Errorf("expecting a nesting cycle adding %s, got %v\n", g.Name, err)
+		}
+	}
+
+	err = ftd.DeleteFromNetworkObjectGroup(outer, inner)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(outer.Objects) != 1 {
+		t.Errorf("expecting 1 object left, got %d\n", len(outer.Objects))
+	}
+}
+
+func TestCreateNetworkObjectGroupPrefixName(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n, _ := NewHostObject("testPrefixGroupObj001", "192.0.2.77")
+	err = ftd.CreateNetworkObject(n, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObject(n)
+
+	for _, name := range []string{"testPrefixGroup10", "testPrefixGroup1"} {
+		g := new(NetworkObjectGroup)
+		g.Name = name
+		g.Objects = append(g.Objects, n.Reference())
+
+		err = ftd.CreateNetworkObjectGroup(g, DuplicateActionError)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+		defer ftd.DeleteNetworkObjectGroup(g)
+
+		if g.Name != name {
+			t.Errorf("expecting %s, got %s\n", name, g.Name)
+		}
+	}
+}
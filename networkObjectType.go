@@ -0,0 +1,181 @@
+package goftd
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NewHostObject Returns a HOST network object for a single address, e.g. 192.168.1.1
+func NewHostObject(name, ip string) (*NetworkObject, error) {
+	n := &NetworkObject{
+		SubType: NetworkObjectSubTypeHost,
+		Value:   ip,
+	}
+	n.Name = name
+
+	return n, n.Validate()
+}
+
+// NewNetworkObject Returns a NETWORK network object for a CIDR, e.g. 192.168.1.0/24
+func NewNetworkObject(name, cidr string) (*NetworkObject, error) {
+	n := &NetworkObject{
+		SubType: NetworkObjectSubTypeNetwork,
+		Value:   cidr,
+	}
+	n.Name = name
+
+	return n, n.Validate()
+}
+
+// NewRangeObject Returns a RANGE network object from first to last, e.g. 192.168.1.10 and 192.168.1.20
+func NewRangeObject(name, first, last string) (*NetworkObject, error) {
+	n := &NetworkObject{
+		SubType: NetworkObjectSubTypeRange,
+		Value:   fmt.Sprintf("%s-%s", first, last),
+	}
+	n.Name = name
+
+	return n, n.Validate()
+}
+
+// NewFQDNObject Returns a FQDN network object, dnsResolution is one of the DNSResolution constants
+// and defaults to DNSResolutionIPv4AndIPv6 when empty
+func NewFQDNObject(name, fqdn, dnsResolution string) (*NetworkObject, error) {
+	if dnsResolution == "" {
+		dnsResolution = DNSResolutionIPv4AndIPv6
+	}
+
+	n := &NetworkObject{
+		SubType:       NetworkObjectSubTypeFQDN,
+		Value:         fqdn,
+		DNSResolution: dnsResolution,
+	}
+	n.Name = name
+
+	return n, n.Validate()
+}
+
+// Validate Checks the value of the object matches its subtype
+func (n *NetworkObject) Validate() error {
+	if n.SubType != NetworkObjectSubTypeFQDN && n.DNSResolution != "" {
+		return fmt.Errorf("network object %s: dnsResolution is only valid for %s", n.Name, NetworkObjectSubTypeFQDN)
+	}
+
+	switch n.SubType {
+	case NetworkObjectSubTypeHost:
+		if net.ParseIP(n.Value) == nil {
+			return fmt.Errorf("network object %s: invalid address: %s", n.Name, n.Value)
+		}
+	case NetworkObjectSubTypeNetwork:
+		ip, ipNet, err := net.ParseCIDR(n.Value)
+		if err != nil {
+			return fmt.Errorf("network object %s: invalid CIDR: %s", n.Name, n.Value)
+		}
+		if !ip.Equal(ipNet.IP) {
+			return fmt.Errorf("network object %s: %s has host bits set, expecting %s", n.Name, n.Value, ipNet)
+		}
+	case NetworkObjectSubTypeRange:
+		_, _, err := parseRange(n.Value)
+		if err != nil {
+			return fmt.Errorf("network object %s: %s", n.Name, err)
+		}
+	case NetworkObjectSubTypeFQDN:
+		if !isHostname(n.Value) {
+			return fmt.Errorf("network object %s: invalid FQDN: %s", n.Name, n.Value)
+		}
+		switch n.DNSResolution {
+		case "", DNSResolutionIPv4Only, DNSResolutionIPv6Only, DNSResolutionIPv4AndIPv6:
+		default:
+			return fmt.Errorf("network object %s: invalid dnsResolution: %s", n.Name, n.DNSResolution)
+		}
+	default:
+		return fmt.Errorf("network object %s: unknown subtype: %s", n.Name, n.SubType)
+	}
+
+	return nil
+}
+
+// ParseNetworkObject Returns an unsaved network object for an IP, CIDR, range (a-b) or hostname.
+// The object is named after its value, CIDR get an underscore instead of the slash.
+func ParseNetworkObject(value string) (*NetworkObject, error) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case net.ParseIP(value) != nil:
+		return NewHostObject(value, value)
+	case strings.Contains(value, "/"):
+		return parseNetwork(value)
+	case strings.Contains(value, "-") && net.ParseIP(strings.SplitN(value, "-", 2)[0]) != nil:
+		first, last, err := parseRange(value)
+		if err != nil {
+			return nil, err
+		}
+		return NewRangeObject(value, first.String(), last.String())
+	case isHostname(value):
+		return NewFQDNObject(value, value, "")
+	}
+
+	return nil, fmt.Errorf("unable to parse network object: %s", value)
+}
+
+// parseNetwork Returns a NETWORK object for cidr, FDM rejects networks with host bits set
+// so 10.0.0.1/24 becomes 10.0.0.0/24
+func parseNetwork(cidr string) (*NetworkObject, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR: %s", cidr)
+	}
+
+	return NewNetworkObject(strings.Replace(ipNet.String(), "/", "_", 1), ipNet.String())
+}
+
+// parseRange Returns the first and last address of a range a-b
+func parseRange(value string) (net.IP, net.IP, error) {
+	parts := strings.SplitN(value, "-", 2)
+	if len(parts) != 2 {
+		return nil, nil, fmt.Errorf("invalid range: %s", value)
+	}
+
+	first := net.ParseIP(strings.TrimSpace(parts[0]))
+	last := net.ParseIP(strings.TrimSpace(parts[1]))
+	if first == nil || last == nil {
+		return nil, nil, fmt.Errorf("invalid range: %s", value)
+	}
+
+	if (first.To4() == nil) != (last.To4() == nil) {
+		return nil, nil, fmt.Errorf("invalid range: %s mixes IPv4 and IPv6", value)
+	}
+
+	if bytes.Compare(first.To16(), last.To16()) > 0 {
+		return nil, nil, fmt.Errorf("invalid range: %s starts after it ends", value)
+	}
+
+	return first, last, nil
+}
+
+// isHostname Returns true if s is a valid DNS name, labels are letters, digits and hyphens
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if len(s) == 0 || len(s) > 253 {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	for _, l := range labels {
+		if len(l) == 0 || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+
+		for _, c := range l {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	// All numeric names are malformed addresses, not hostnames
+	last := labels[len(labels)-1]
+	return strings.Trim(last, "0123456789") != ""
+}
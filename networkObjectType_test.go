@@ -0,0 +1,79 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestNetworkObjectValidate(t *testing.T) {
+	tests := []struct {
+		subType       string
+		value         string
+		dnsResolution string
+		valid         bool
+	}{
+		{NetworkObjectSubTypeHost, "1.1.1.1", "", true},
+		{NetworkObjectSubTypeHost, "2001:db8::1", "", true},
+		{NetworkObjectSubTypeHost, "1.1.1.1/32", "", false},
+		{NetworkObjectSubTypeHost, "1.1.1.1", DNSResolutionIPv4Only, false},
+		{NetworkObjectSubTypeNetwork, "10.0.0.0/8", "", true},
+		{NetworkObjectSubTypeNetwork, "10.0.0.1/8", "", false},
+		{NetworkObjectSubTypeNetwork, "10.0.0.0", "", false},
+		{NetworkObjectSubTypeRange, "10.0.0.1-10.0.0.10", "", true},
+		{NetworkObjectSubTypeRange, "10.0.0.10-10.0.0.1", "", false},
+		{NetworkObjectSubTypeRange, "10.0.0.1-2001:db8::1", "", false},
+		{NetworkObjectSubTypeFQDN, "www.example.com", DNSResolutionIPv4AndIPv6, true},
+		{NetworkObjectSubTypeFQDN, "www.example.com", "", true},
+		{NetworkObjectSubTypeFQDN, "www.example.com", "IPV5", false},
+		{NetworkObjectSubTypeFQDN, "-www.example.com", "", false},
+		{NetworkObjectSubTypeFQDN, "1.1.1.256", "", false},
+		{"UNKNOWN", "1.1.1.1", "", false},
+	}
+
+	for _, tt := range tests {
+		n := &NetworkObject{
+			SubType:       tt.subType,
+			Value:         tt.value,
+			DNSResolution: tt.dnsResolution,
+		}
+
+		err := n.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("%s %s: unexpected error: %s\n", tt.subType, tt.value, err)
+		} else if !tt.valid && err == nil {
+			t.Errorf("%s %s: expecting an error\n", tt.subType, tt.value)
+		}
+	}
+}
+
+func TestParseNetworkObject(t *testing.T) {
+	tests := []struct {
+		value   string
+		name    string
+		subType string
+		want    string
+	}{
+		{"1.1.1.1", "1.1.1.1", NetworkObjectSubTypeHost, "1.1.1.1"},
+		{"10.1.2.3/16", "10.1.0.0_16", NetworkObjectSubTypeNetwork, "10.1.0.0/16"},
+		{"10.0.0.1-10.0.0.10", "10.0.0.1-10.0.0.10", NetworkObjectSubTypeRange, "10.0.0.1-10.0.0.10"},
+		{"www.example.com", "www.example.com", NetworkObjectSubTypeFQDN, "www.example.com"},
+	}
+
+	for _, tt := range tests {
+		n, err := ParseNetworkObject(tt.value)
+		if err != nil {
+			t.Errorf("%s: error: %s\n", tt.value, err)
+			continue
+		}
+
+		if n.Name != tt.name || n.SubType != tt.subType || n.Value != tt.want {
+			t.Errorf("%s: expecting %s %s %s, got %s %s %s\n", tt.value, tt.name, tt.subType, tt.want, n.Name, n.SubType, n.Value)
+		}
+	}
+
+	for _, value := range []string{"", "10.0.0.1/33", "10.0.0.10-10.0.0.1", "not a host"} {
+		_, err := ParseNetworkObject(value)
+		if err == nil {
+			t.Errorf("%q: expecting an error\n", value)
+		}
+	}
+}
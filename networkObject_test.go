@@ -1,6 +1,7 @@
 package goftd
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/glog"
@@ -19,7 +20,7 @@ func TestGetNetworkObject(t *testing.T) {
 		return
 	}
 
-	obj2, err := ftd.getNetworkObjectBy("name:any-ipv4", 0)
+	obj2, err := ftd.getNetworkObjectBy(context.Background(), "name:any-ipv4", 0)
 	if err != nil {
 		t.Errorf("error: %s\n", err)
 		return
@@ -34,6 +35,34 @@ func TestGetNetworkObject(t *testing.T) {
 	}
 }
 
+func TestIterNetworkObjects(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	all, err := ftd.GetNetworkObjects(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	count := 0
+	err = ftd.IterNetworkObjects(context.Background(), func(n *NetworkObject) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if count != len(all) {
+		t.Errorf("expecting %d objects, got %d\n", len(all), count)
+	}
+}
+
 func TestCreateNetworkObject(t *testing.T) {
 	var err error
 
@@ -268,3 +297,92 @@ func TestCreateNetworkObjectFromIPs(t *testing.T) {
 	}
 
 }
+
+func TestCreateNetworkObjectPrefixName(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	// The name filter matches substrings, 10.9.9.1 matches 10.9.9.10 too
+	var objs []*NetworkObject
+	for _, ip := range []string{"10.9.9.10", "10.9.9.1"} {
+		n, _ := NewHostObject(ip, ip)
+
+		err = ftd.CreateNetworkObject(n, DuplicateActionError)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+		defer ftd.DeleteNetworkObject(n)
+
+		if n.Name != ip || n.Value != ip {
+			t.Errorf("expecting %s, got %s with value %s\n", ip, n.Name, n.Value)
+		}
+		objs = append(objs, n)
+	}
+
+	if objs[0].ID == objs[1].ID {
+		t.Errorf("expecting 2 objects, got %s twice\n", objs[0].ID)
+	}
+
+	n, _ := NewHostObject("10.9.9.1", "10.9.9.1")
+	err = ftd.CreateNetworkObject(n, DuplicateActionDoNothing)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if n.ID != objs[1].ID {
+		t.Errorf("expecting %s, got %s\n", objs[1].ID, n.ID)
+	}
+}
+
+func TestCreateNetworkObjectsFromStringsPrefixName(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	// Objects are named after their value, 10.0.0.1 is a prefix of 10.0.0.10 and 10.0.0.0_1 of 10.0.0.0_16
+	values := []string{"10.0.0.10", "10.0.0.1", "10.0.0.0/16", "10.0.0.0/8"}
+	names := []string{"10.0.0.10", "10.0.0.1", "10.0.0.0_16", "10.0.0.0_8"}
+
+	ns, err := ftd.CreateNetworkObjectsFromStrings(values)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	for i := range ns {
+		defer ftd.DeleteNetworkObject(ns[i])
+	}
+
+	if len(ns) != len(values) {
+		t.Errorf("expecting %d objects, got %d\n", len(values), len(ns))
+		return
+	}
+
+	ids := make(map[string]bool)
+	for i := range ns {
+		if ns[i].Name != names[i] || ns[i].Value != values[i] {
+			t.Errorf("expecting %s with value %s, got %s with value %s\n", names[i], values[i], ns[i].Name, ns[i].Value)
+		}
+		ids[ns[i].ID] = true
+	}
+	if len(ids) != len(values) {
+		t.Errorf("expecting %d distinct objects, got %d\n", len(values), len(ids))
+	}
+
+	hosts, err := ftd.CreateNetworkObjectsFromIPs([]string{"10.0.0.1", "10.0.0.10"})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if len(hosts) != 2 || hosts[0].ID != ns[1].ID || hosts[1].ID != ns[0].ID {
+		t.Errorf("expecting the hosts to be reused, got %+v\n", hosts)
+	}
+
+	networks, err := ftd.CreateNetworkObjectsFromCIDRs([]string{"10.0.0.0/8", "10.0.0.0/16"})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if len(networks) != 2 || networks[0].ID != ns[3].ID || networks[1].ID != ns[2].ID {
+		t.Errorf("expecting the networks to be reused, got %+v\n", networks)
+	}
+}
@@ -0,0 +1,248 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ObjectNATRule NAT rule translating a single network object, also known as auto NAT.
+// The device orders object NAT rules itself, they can't be positioned.
+type ObjectNATRule struct {
+	ReferenceObject
+	Description          string           `json:"description,omitempty"`
+	SourceInterface      *ReferenceObject `json:"sourceInterface,omitempty"`
+	DestinationInterface *ReferenceObject `json:"destinationInterface,omitempty"`
+	NATType              string           `json:"natType,omitempty"`
+	OriginalNetwork      *ReferenceObject `json:"originalNetwork,omitempty"`
+	OriginalPort         *ReferenceObject `json:"originalPort,omitempty"`
+	TranslatedNetwork    *ReferenceObject `json:"translatedNetwork,omitempty"`
+	TranslatedPort       *ReferenceObject `json:"translatedPort,omitempty"`
+	// InterfacePAT translates to the address of the destination interface
+	InterfacePAT  bool   `json:"interfaceInTranslatedNetwork,omitempty"`
+	InterfaceIPv6 bool   `json:"interfaceIPv6,omitempty"`
+	NetToNet      bool   `json:"netToNet,omitempty"`
+	NoProxyARP    bool   `json:"noProxyArp,omitempty"`
+	RouteLookup   bool   `json:"routeLookup,omitempty"`
+	DNS           bool   `json:"dns,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	Links         *Links `json:"links,omitempty"`
+	parent        string
+}
+
+// Reference Returns a reference object
+func (n *ObjectNATRule) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      n.ID,
+		Name:    n.Name,
+		Version: n.Version,
+		Type:    n.Type,
+	}
+
+	return &r
+}
+
+// GetObjectNATRules Get a list of object NAT rules, a limit of 0 returns all of them
+func (f *FTD) GetObjectNATRules(policy string, limit int) ([]*ObjectNATRule, error) {
+	return f.GetObjectNATRulesContext(context.Background(), policy, limit)
+}
+
+// GetObjectNATRulesContext Same as GetObjectNATRules, ctx cancels the requests
+func (f *FTD) GetObjectNATRulesContext(ctx context.Context, policy string, limit int) ([]*ObjectNATRule, error) {
+	var err error
+	var retval []*ObjectNATRule
+
+	err = f.iterObjectNATRules(ctx, policy, nil, limit, func(n *ObjectNATRule) error {
+		retval = append(retval, n)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterObjectNATRules Calls fn for every object NAT rule of a policy, fetching one page at a time
+func (f *FTD) IterObjectNATRules(ctx context.Context, policy string, fn func(*ObjectNATRule) error) error {
+	return f.iterObjectNATRules(ctx, policy, nil, 0, fn)
+}
+
+func (f *FTD) iterObjectNATRules(ctx context.Context, policy string, query map[string]string, limit int, fn func(*ObjectNATRule) error) error {
+	endpoint := fmt.Sprintf("%s/%s/objectnatrules", apiObjectNATPoliciesEndpoint, policy)
+	return f.iterate(ctx, endpoint, query, limit, func(item json.RawMessage) error {
+		var n *ObjectNATRule
+
+		err := json.Unmarshal(item, &n)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		n.parent = policy
+		return fn(n)
+	})
+}
+
+// GetObjectNATRuleByID Get an object NAT rule by ID
+func (f *FTD) GetObjectNATRuleByID(policy, id string) (*ObjectNATRule, error) {
+	return f.GetObjectNATRuleByIDContext(context.Background(), policy, id)
+}
+
+// GetObjectNATRuleByIDContext Same as GetObjectNATRuleByID, ctx cancels the requests
+func (f *FTD) GetObjectNATRuleByIDContext(ctx context.Context, policy, id string) (*ObjectNATRule, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s/objectnatrules/%s", apiObjectNATPoliciesEndpoint, policy, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *ObjectNATRule
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	v.parent = policy
+	return v, nil
+}
+
+// GetObjectNATRuleByName Get an object NAT rule by name
+func (f *FTD) GetObjectNATRuleByName(policy, name string) (*ObjectNATRule, error) {
+	return f.GetObjectNATRuleByNameContext(context.Background(), policy, name)
+}
+
+// GetObjectNATRuleByNameContext Same as GetObjectNATRuleByName, ctx cancels the requests
+func (f *FTD) GetObjectNATRuleByNameContext(ctx context.Context, policy, name string) (*ObjectNATRule, error) {
+	var retval *ObjectNATRule
+
+	filter := make(map[string]string)
+	filter["filter"] = fmt.Sprintf("name:%s", name)
+
+	err := f.iterObjectNATRules(ctx, policy, filter, 0, func(n *ObjectNATRule) error {
+		if n.Name == name {
+			retval = n
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("object NAT rule %s: %w", name, ErrNotFound)
+	}
+
+	return retval, nil
+}
+
+// CreateObjectNATRule Create a new object NAT rule
+func (f *FTD) CreateObjectNATRule(n *ObjectNATRule, policy string) error {
+	return f.CreateObjectNATRuleContext(context.Background(), n, policy)
+}
+
+// CreateObjectNATRuleContext Same as CreateObjectNATRule, ctx cancels the requests
+func (f *FTD) CreateObjectNATRuleContext(ctx context.Context, n *ObjectNATRule, policy string) error {
+	var err error
+
+	n.Type = "objectnatrule"
+
+	endpoint := fmt.Sprintf("%s/%s/objectnatrules", apiObjectNATPoliciesEndpoint, policy)
+	data, err := f.PostContext(ctx, endpoint, n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	n.parent = policy
+
+	return nil
+}
+
+// UpdateObjectNATRule Updates an object NAT rule
+func (f *FTD) UpdateObjectNATRule(n *ObjectNATRule) error {
+	return f.UpdateObjectNATRuleContext(context.Background(), n)
+}
+
+// UpdateObjectNATRuleContext Same as UpdateObjectNATRule, ctx cancels the requests
+func (f *FTD) UpdateObjectNATRuleContext(ctx context.Context, n *ObjectNATRule) error {
+	var err error
+
+	if n.parent == "" {
+		err = errNoParent("object NAT rule", n.ID, "policy", "GetObjectNATRuleByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/objectnatrules/%s", apiObjectNATPoliciesEndpoint, n.parent, n.ID)
+	data, err := f.PutContext(ctx, endpoint, n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &n)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteObjectNATRule Delete an object NAT rule
+func (f *FTD) DeleteObjectNATRule(n *ObjectNATRule) error {
+	return f.DeleteObjectNATRuleContext(context.Background(), n)
+}
+
+// DeleteObjectNATRuleContext Same as DeleteObjectNATRule, ctx cancels the requests
+func (f *FTD) DeleteObjectNATRuleContext(ctx context.Context, n *ObjectNATRule) error {
+	var err error
+
+	if n.parent == "" {
+		err = errNoParent("object NAT rule", n.ID, "policy", "GetObjectNATRuleByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/objectnatrules/%s", apiObjectNATPoliciesEndpoint, n.parent, n.ID)
+	err = f.DeleteContext(ctx, endpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package goftd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Option Configures an FTD session, see New
+type Option func(*FTD) error
+
+// setGrant makes sure a single grant is configured
+func (f *FTD) setGrant(grantType string) error {
+	if f.GrantType != "" {
+		return fmt.Errorf("grant type is already set to %s", f.GrantType)
+	}
+
+	f.GrantType = grantType
+	return nil
+}
+
+// WithPasswordGrant Authenticates with a username and password
+func WithPasswordGrant(username, password string) Option {
+	return func(f *FTD) error {
+		if username == "" {
+			return fmt.Errorf("username is mandatory for grant type = %s", grantTypePassword)
+		}
+
+		if password == "" {
+			return fmt.Errorf("password is mandatory for grant type = %s", grantTypePassword)
+		}
+
+		err := f.setGrant(grantTypePassword)
+		if err != nil {
+			return err
+		}
+
+		f.passwordGrant = &passwordGrant{
+			Username: username,
+			Password: password,
+		}
+
+		return nil
+	}
+}
+
+// WithCustomGrant Authenticates by exchanging an existing access token for a custom token.
+// subject and lifetimes are optional.
+func WithCustomGrant(accessToken, subject string, expiresIn, refreshExpiresIn time.Duration) Option {
+	return func(f *FTD) error {
+		if accessToken == "" {
+			return fmt.Errorf("access_token is mandatory for grant type = %s", grantTypeCustom)
+		}
+
+		if expiresIn < 0 || refreshExpiresIn < 0 {
+			return fmt.Errorf("token lifetimes can't be negative")
+		}
+
+		err := f.setGrant(grantTypeCustom)
+		if err != nil {
+			return err
+		}
+
+		f.customGrant = &customGrant{
+			AccessToken:             accessToken,
+			DesiredSubject:          subject,
+			DesiredExpiresIn:        int(expiresIn / time.Second),
+			DesiredRefreshExpiresIn: int(refreshExpiresIn / time.Second),
+		}
+
+		return nil
+	}
+}
+
+// WithInsecureTLS Skips the verification of the device certificate
+func WithInsecureTLS() Option {
+	return func(f *FTD) error {
+		f.Insecure = true
+		return nil
+	}
+}
+
+// WithCACertPool Verifies the device certificate against pool, e.g. for self-signed certificates
+func WithCACertPool(pool *x509.CertPool) Option {
+	return func(f *FTD) error {
+		if pool == nil {
+			return fmt.Errorf("CA cert pool can't be nil")
+		}
+
+		f.rootCAs = pool
+		return nil
+	}
+}
+
+// WithCACertFile Verifies the device certificate against the PEM encoded certificates in path
+func WithCACertFile(path string) Option {
+	return func(f *FTD) error {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in %s", path)
+		}
+
+		f.rootCAs = pool
+		return nil
+	}
+}
+
+// WithCertificateFingerprint Pins the SHA-256 fingerprint of the device certificate, in hex with or without colons
+func WithCertificateFingerprint(fingerprint string) Option {
+	return func(f *FTD) error {
+		b, err := parseFingerprint(fingerprint)
+		if err != nil {
+			return err
+		}
+
+		f.fingerprint = b
+		return nil
+	}
+}
+
+// WithClientCertificate Presents cert to the device
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(f *FTD) error {
+		if len(cert.Certificate) == 0 {
+			return fmt.Errorf("client certificate is empty")
+		}
+
+		f.clientCerts = append(f.clientCerts, cert)
+		return nil
+	}
+}
+
+// WithHTTPClient Sends the requests with c instead of the default client
+func WithHTTPClient(c *http.Client) Option {
+	return func(f *FTD) error {
+		if c == nil {
+			return fmt.Errorf("HTTP client can't be nil")
+		}
+
+		f.client = c
+		return nil
+	}
+}
+
+// WithTransport Wraps the transport of the HTTP client with wrap, e.g. to record or replay the requests.
+// wrap receives the transport the requests would otherwise go through.
+func WithTransport(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(f *FTD) error {
+		if wrap == nil {
+			return fmt.Errorf("transport wrapper can't be nil")
+		}
+
+		f.wrapTransport = wrap
+		return nil
+	}
+}
+
+// WithTimeout Limits the time taken by each request
+func WithTimeout(d time.Duration) Option {
+	return func(f *FTD) error {
+		if d <= 0 {
+			return fmt.Errorf("invalid timeout: %s", d)
+		}
+
+		f.timeout = d
+		return nil
+	}
+}
+
+// WithLogger Sends the log messages to l instead of glog
+func WithLogger(l Logger) Option {
+	return func(f *FTD) error {
+		if l == nil {
+			return fmt.Errorf("logger can't be nil")
+		}
+
+		f.logger = l
+		return nil
+	}
+}
+
+// WithPort Connects to the device on port instead of 443
+func WithPort(port int) Option {
+	return func(f *FTD) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port: %d", port)
+		}
+
+		f.port = port
+		return nil
+	}
+}
+
+// WithRetryPolicy Retries the requests failing with a transient error according to p, see RetryPolicy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(f *FTD) error {
+		if p.MaxAttempts < 1 {
+			return fmt.Errorf("invalid max attempts: %d", p.MaxAttempts)
+		}
+
+		if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+			return fmt.Errorf("backoff can't be negative")
+		}
+
+		f.retryPolicy = p
+		return nil
+	}
+}
+
+// WithDebug Logs the errors encountered
+func WithDebug() Option {
+	return func(f *FTD) error {
+		f.debug = true
+		return nil
+	}
+}
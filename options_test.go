@@ -0,0 +1,133 @@
+package goftd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/remiphilippe/go-ftd/ftdtest"
+)
+
+func TestOptionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"no grant", []Option{WithInsecureTLS()}},
+		{"two grants", []Option{WithPasswordGrant("admin", "secret"), WithCustomGrant("token", "", 0, 0)}},
+		{"empty username", []Option{WithPasswordGrant("", "secret")}},
+		{"empty token", []Option{WithCustomGrant("", "ci", time.Hour, 0)}},
+		{"invalid port", []Option{WithPasswordGrant("admin", "secret"), WithPort(70000)}},
+		{"invalid timeout", []Option{WithPasswordGrant("admin", "secret"), WithTimeout(0)}},
+		{"nil client", []Option{WithPasswordGrant("admin", "secret"), WithHTTPClient(nil)}},
+		{"nil pool", []Option{WithPasswordGrant("admin", "secret"), WithCACertPool(nil)}},
+		{"nil logger", []Option{WithPasswordGrant("admin", "secret"), WithLogger(nil)}},
+		{"nil transport", []Option{WithPasswordGrant("admin", "secret"), WithTransport(nil)}},
+	}
+
+	for _, tt := range tests {
+		_, err := New("ftd.example.com", tt.opts...)
+		if err == nil {
+			t.Errorf("%s: expecting an error\n", tt.name)
+		}
+	}
+}
+
+func TestNewFTDParams(t *testing.T) {
+	params := make(map[string]string)
+	params["grant_type"] = "password"
+	params["username"] = "admin"
+
+	_, err := NewFTD("ftd.example.com", params)
+	if err == nil {
+		t.Errorf("expecting an error for missing password\n")
+	}
+
+	params["grant_type"] = "oauth"
+	_, err = NewFTD("ftd.example.com", params)
+	if err == nil {
+		t.Errorf("expecting an error for unknown grant type\n")
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := ftdtest.NewServer()
+
+	dir, err := ioutil.TempDir("", "goftd")
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "networks.json")
+
+	session := func(ftd *FTD) *NetworkObject {
+		n, err := NewHostObject("testObj001", "192.0.2.1")
+		if err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+
+		err = ftd.CreateNetworkObject(n, DuplicateActionError)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return n
+		}
+
+		_, err = ftd.GetNetworkObjectByID(n.ID)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+
+		err = ftd.DeleteNetworkObject(n)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+
+		return n
+	}
+
+	rec := ftdtest.NewRecorder()
+	ftd, err := New(srv.Host(), WithPasswordGrant(ftdtest.Username, ftdtest.Password), WithInsecureTLS(), WithTransport(rec.Wrap))
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	recorded := session(ftd)
+	srv.Close()
+
+	err = rec.Save(path)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	for _, secret := range []string{ftdtest.Password, ftd.accessToken, ftd.refreshToken, srv.Host()} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("cassette contains %s\n", secret)
+		}
+	}
+
+	rp, err := ftdtest.NewReplayerFromFile(path)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	ftd, err = New("ftd.example.com", WithPasswordGrant("admin", "password"), WithTransport(rp.Wrap))
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	replayed := session(ftd)
+	if replayed.ID != recorded.ID || replayed.Version != recorded.Version {
+		t.Errorf("expecting %s/%s, got %s/%s\n", recorded.ID, recorded.Version, replayed.ID, replayed.Version)
+	}
+
+	if n := rp.Remaining(); n != 0 {
+		t.Errorf("expecting every interaction to be replayed, %d left\n", n)
+	}
+}
@@ -0,0 +1,149 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// errStopIteration returned by an iteration callback to stop without error
+var errStopIteration = errors.New("stop iteration")
+
+// page A single page returned by a list endpoint
+type page struct {
+	Items  []json.RawMessage `json:"items"`
+	Paging *Paging           `json:"paging,omitempty"`
+}
+
+// nextOffset Returns the offset of the next page, or -1 when there is none
+func (p *page) nextOffset(offset int) int {
+	if len(p.Items) == 0 || p.Paging == nil || len(p.Paging.Next) == 0 {
+		return -1
+	}
+
+	// Prefer the offset FDM advertises in the next link
+	u, err := url.Parse(p.Paging.Next[0])
+	if err == nil {
+		if o, err := strconv.Atoi(u.Query().Get("offset")); err == nil && o > offset {
+			return o
+		}
+	}
+
+	return offset + len(p.Items)
+}
+
+// iterate Walks every page of a list endpoint and calls fn for each raw item.
+// A limit of 0 walks all the pages, otherwise iteration stops after limit items.
+// An error returned by fn stops the iteration and is returned as is, except errStopIteration.
+func (f *FTD) iterate(ctx context.Context, endpoint string, query map[string]string, limit int, fn func(item json.RawMessage) error) error {
+	var err error
+
+	pageLimit := apiPageLimit
+	if limit > 0 && limit < pageLimit {
+		pageLimit = limit
+	}
+
+	count := 0
+	offset := 0
+	for offset >= 0 {
+		err = ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		r := requestParameters{
+			URIQuery:  query,
+			PageStart: offset,
+			PageLimit: pageLimit,
+		}
+
+		data, err := f.request(ctx, endpoint, apiGET, &r)
+		if err != nil {
+			return err
+		}
+
+		var p page
+		err = json.Unmarshal(data, &p)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		for i := range p.Items {
+			if limit > 0 && count >= limit {
+				return nil
+			}
+
+			err = fn(p.Items[i])
+			if err == errStopIteration {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			count++
+		}
+
+		if limit > 0 && count >= limit {
+			return nil
+		}
+
+		offset = p.nextOffset(offset)
+	}
+
+	return nil
+}
+
+// findByName Looks up the item of endpoint named name and unmarshals it into v
+func (f *FTD) findByName(ctx context.Context, endpoint, name string, v interface{}) error {
+	var found json.RawMessage
+
+	query := make(map[string]string)
+	query["filter"] = fmt.Sprintf("name:%s", name)
+
+	err := f.iterate(ctx, endpoint, query, 0, func(item json.RawMessage) error {
+		var r ReferenceObject
+
+		err := json.Unmarshal(item, &r)
+		if err != nil {
+			return err
+		}
+
+		// The filter matches substrings, keep the exact match
+		if r.Name == name {
+			found = item
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	if found == nil {
+		return fmt.Errorf("%s %s: %w", endpoint, name, ErrNotFound)
+	}
+
+	return json.Unmarshal(found, v)
+}
+
+// findCreated Looks up the object named name right after its creation. Not finding it isn't ErrNotFound,
+// the object was just created or already existed.
+func (f *FTD) findCreated(ctx context.Context, endpoint, name string, v interface{}) error {
+	err := f.findByName(ctx, endpoint, name, v)
+	if IsNotFound(err) {
+		err = fmt.Errorf("%s %s can't be found once created", endpoint, name)
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+	}
+
+	return err
+}
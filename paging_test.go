@@ -0,0 +1,75 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNextOffset(t *testing.T) {
+	items := []json.RawMessage{json.RawMessage(`{}`), json.RawMessage(`{}`)}
+
+	p := page{Items: items}
+	if o := p.nextOffset(0); o != -1 {
+		t.Errorf("expecting -1 without paging, got %d\n", o)
+	}
+
+	p.Paging = &Paging{Next: []string{"https://ftd/api/fdm/v1/object/networks?offset=10&limit=2"}}
+	if o := p.nextOffset(0); o != 10 {
+		t.Errorf("expecting offset 10 from next link, got %d\n", o)
+	}
+
+	p.Paging = &Paging{Next: []string{"not a link"}}
+	if o := p.nextOffset(4); o != 6 {
+		t.Errorf("expecting offset 6, got %d\n", o)
+	}
+
+	p.Items = nil
+	if o := p.nextOffset(4); o != -1 {
+		t.Errorf("expecting -1 on empty page, got %d\n", o)
+	}
+}
+
+func TestIterateLimit(t *testing.T) {
+	calls := 0
+	h := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var items []string
+		for i := offset; i < offset+limit; i++ {
+			items = append(items, fmt.Sprintf(`{"id":"%d"}`, i))
+		}
+
+		// Always advertise a next page
+		fmt.Fprintf(w, `{"items":[%s],"paging":{"next":["https://ftd/api/fdm/v1/object/networks?offset=%d&limit=%d"]}}`,
+			strings.Join(items, ","), offset+limit, limit)
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+
+	count := 0
+	err := ftd.iterate(context.Background(), apiNetworksEndpoint, nil, 2*apiPageLimit, func(item json.RawMessage) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if count != 2*apiPageLimit {
+		t.Errorf("expecting %d items, got %d\n", 2*apiPageLimit, count)
+	}
+
+	if calls != 2 {
+		t.Errorf("expecting 2 pages to be fetched, got %d\n", calls)
+	}
+}
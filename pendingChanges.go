@@ -0,0 +1,132 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// PendingChange A change staged on the device and not yet deployed
+type PendingChange struct {
+	EntityID   string          `json:"entityId"`
+	EntityName string          `json:"entityName,omitempty"`
+	EntityType string          `json:"entityType"`
+	ChangeType string          `json:"changeType"`
+	Before     json.RawMessage `json:"entityBefore,omitempty"`
+	After      json.RawMessage `json:"entityAfter,omitempty"`
+	Type       string          `json:"type,omitempty"`
+}
+
+// Reference Returns a reference object to the changed entity
+func (p *PendingChange) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:   p.EntityID,
+		Name: p.EntityName,
+		Type: p.EntityType,
+	}
+
+	return &r
+}
+
+// DecodeBefore Unmarshals the entity as it was before the change, e.g. into a *NetworkObject
+func (p *PendingChange) DecodeBefore(v interface{}) error {
+	if len(p.Before) == 0 {
+		return nil
+	}
+	return json.Unmarshal(p.Before, v)
+}
+
+// DecodeAfter Unmarshals the entity as it will be after the change, e.g. into an *AccessRule
+func (p *PendingChange) DecodeAfter(v interface{}) error {
+	if len(p.After) == 0 {
+		return nil
+	}
+	return json.Unmarshal(p.After, v)
+}
+
+// GetPendingChanges Get a list of pending changes, a limit of 0 returns all of them
+func (f *FTD) GetPendingChanges(limit int) ([]*PendingChange, error) {
+	return f.GetPendingChangesContext(context.Background(), limit)
+}
+
+// GetPendingChangesContext Same as GetPendingChanges, ctx cancels the requests
+func (f *FTD) GetPendingChangesContext(ctx context.Context, limit int) ([]*PendingChange, error) {
+	var err error
+	var retval []*PendingChange
+
+	err = f.iterPendingChanges(ctx, limit, func(p *PendingChange) error {
+		retval = append(retval, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterPendingChanges Calls fn for every pending change, fetching one page at a time
+func (f *FTD) IterPendingChanges(ctx context.Context, fn func(*PendingChange) error) error {
+	return f.iterPendingChanges(ctx, 0, fn)
+}
+
+func (f *FTD) iterPendingChanges(ctx context.Context, limit int, fn func(*PendingChange) error) error {
+	return f.iterate(ctx, apiPendingChangesEndpoint, nil, limit, func(item json.RawMessage) error {
+		var p *PendingChange
+
+		err := json.Unmarshal(item, &p)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(p)
+	})
+}
+
+// GetPendingChangesFor Get the pending changes of a single object, e.g. n.Reference() for a NetworkObject
+func (f *FTD) GetPendingChangesFor(ref *ReferenceObject) ([]*PendingChange, error) {
+	return f.GetPendingChangesForContext(context.Background(), ref)
+}
+
+// GetPendingChangesForContext Same as GetPendingChangesFor, ctx cancels the requests
+func (f *FTD) GetPendingChangesForContext(ctx context.Context, ref *ReferenceObject) ([]*PendingChange, error) {
+	var err error
+	var retval []*PendingChange
+
+	err = f.iterPendingChanges(ctx, 0, func(p *PendingChange) error {
+		if p.EntityID == ref.ID {
+			retval = append(retval, p)
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// DiscardPendingChanges Discard all the changes not yet deployed
+func (f *FTD) DiscardPendingChanges() error {
+	return f.DiscardPendingChangesContext(context.Background())
+}
+
+// DiscardPendingChangesContext Same as DiscardPendingChanges, ctx cancels the requests
+func (f *FTD) DiscardPendingChangesContext(ctx context.Context) error {
+	var err error
+
+	err = f.DeleteContext(ctx, apiPendingChangesEndpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
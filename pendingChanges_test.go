@@ -0,0 +1,58 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestPendingChanges(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n := new(NetworkObject)
+	n.Name = "testObj001"
+	n.SubType = "HOST"
+	n.Value = "1.1.1.1"
+
+	err = ftd.CreateNetworkObject(n, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	changes, err := ftd.GetPendingChangesFor(n.Reference())
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(changes) < 1 {
+		t.Errorf("expecting a pending change for %s\n", n.Name)
+	} else {
+		var after NetworkObject
+		err = changes[0].DecodeAfter(&after)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+	}
+
+	err = ftd.DiscardPendingChanges()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	changes, err = ftd.GetPendingChanges(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(changes) != 0 {
+		t.Errorf("expecting no pending changes, got %d\n", len(changes))
+	}
+}
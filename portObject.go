@@ -1,11 +1,11 @@
 package goftd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
-
-	"github.com/golang/glog"
+	"strings"
 )
 
 // PortObject Represents a TCP or UDP port
@@ -29,8 +29,56 @@ func (p *PortObject) Reference() *ReferenceObject {
 	return &r
 }
 
-func (f *FTD) getPortObjects(protocol string, limit int) ([]*PortObject, error) {
+// Validate Checks the port is a single port or a range, e.g. 443 or 1000-2000
+func (p *PortObject) Validate() error {
+	_, _, err := parsePortRange(p.Port)
+	if err != nil {
+		return fmt.Errorf("port object %s: %s", p.Name, err)
+	}
+
+	return nil
+}
+
+// parsePortRange Returns the first and last port of a port or a range a-b
+func parsePortRange(value string) (int, int, error) {
+	parts := strings.SplitN(value, "-", 2)
+
+	first, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || first < 1 || first > 65535 {
+		return 0, 0, fmt.Errorf("invalid port: %s", value)
+	}
+
+	last := first
+	if len(parts) == 2 {
+		last, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || last < 1 || last > 65535 {
+			return 0, 0, fmt.Errorf("invalid port range: %s", value)
+		}
+	}
+
+	if first > last {
+		return 0, 0, fmt.Errorf("invalid port range: %s starts after it ends", value)
+	}
+
+	return first, last, nil
+}
+
+func (f *FTD) getPortObjects(ctx context.Context, protocol string, limit int) ([]*PortObject, error) {
 	var err error
+	var retval []*PortObject
+
+	err = f.iterPortObjects(ctx, protocol, nil, limit, func(p *PortObject) error {
+		retval = append(retval, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+func (f *FTD) iterPortObjects(ctx context.Context, protocol string, query map[string]string, limit int, fn func(*PortObject) error) error {
 	var endpoint string
 
 	switch protocol {
@@ -40,41 +88,52 @@ func (f *FTD) getPortObjects(protocol string, limit int) ([]*PortObject, error)
 		endpoint = apiUDPPortObjectsEndpoint
 	}
 
-	filter := make(map[string]string)
-	filter["limit"] = strconv.Itoa(limit)
-
-	data, err := f.Get(endpoint, filter)
-	if err != nil {
-		return nil, err
-	}
-
-	var v struct {
-		Items []*PortObject `json:"items"`
-		//Paging *Paging       `json:"paging"`
-	}
+	return f.iterate(ctx, endpoint, query, limit, func(item json.RawMessage) error {
+		var p *PortObject
 
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+		err := json.Unmarshal(item, &p)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	return v.Items, nil
+		return fn(p)
+	})
 }
 
 // GetTCPPortObjects Get a list of tcp ports
 func (f *FTD) GetTCPPortObjects() ([]*PortObject, error) {
-	return f.getPortObjects("TCP", 0)
+	return f.GetTCPPortObjectsContext(context.Background())
+}
+
+// GetTCPPortObjectsContext Same as GetTCPPortObjects, ctx cancels the requests
+func (f *FTD) GetTCPPortObjectsContext(ctx context.Context) ([]*PortObject, error) {
+	return f.getPortObjects(ctx, "TCP", 0)
 }
 
 // GetUDPPortObjects Get a list of udp ports
 func (f *FTD) GetUDPPortObjects() ([]*PortObject, error) {
-	return f.getPortObjects("UDP", 0)
+	return f.GetUDPPortObjectsContext(context.Background())
+}
+
+// GetUDPPortObjectsContext Same as GetUDPPortObjects, ctx cancels the requests
+func (f *FTD) GetUDPPortObjectsContext(ctx context.Context) ([]*PortObject, error) {
+	return f.getPortObjects(ctx, "UDP", 0)
+}
+
+// IterTCPPortObjects Calls fn for every tcp port, fetching one page at a time
+func (f *FTD) IterTCPPortObjects(ctx context.Context, fn func(*PortObject) error) error {
+	return f.iterPortObjects(ctx, "TCP", nil, 0, fn)
+}
+
+// IterUDPPortObjects Calls fn for every udp port, fetching one page at a time
+func (f *FTD) IterUDPPortObjects(ctx context.Context, fn func(*PortObject) error) error {
+	return f.iterPortObjects(ctx, "UDP", nil, 0, fn)
 }
 
-func (f *FTD) getPortObjectByID(protocol, id string, limit int) (*PortObject, error) {
+func (f *FTD) getPortObjectByID(ctx context.Context, protocol, id string, limit int) (*PortObject, error) {
 	var err error
 	var endpoint string
 
@@ -88,7 +147,7 @@ func (f *FTD) getPortObjectByID(protocol, id string, limit int) (*PortObject, er
 	filter := make(map[string]string)
 	filter["limit"] = strconv.Itoa(limit)
 
-	data, err := f.Get(endpoint, filter)
+	data, err := f.GetContext(ctx, endpoint, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +157,7 @@ func (f *FTD) getPortObjectByID(protocol, id string, limit int) (*PortObject, er
 	err = json.Unmarshal(data, &v)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
@@ -108,97 +167,78 @@ func (f *FTD) getPortObjectByID(protocol, id string, limit int) (*PortObject, er
 
 // GetTCPPortObjectByID Get a tcp port by ID
 func (f *FTD) GetTCPPortObjectByID(id string) (*PortObject, error) {
-	return f.getPortObjectByID("TCP", id, 0)
+	return f.GetTCPPortObjectByIDContext(context.Background(), id)
+}
+
+// GetTCPPortObjectByIDContext Same as GetTCPPortObjectByID, ctx cancels the requests
+func (f *FTD) GetTCPPortObjectByIDContext(ctx context.Context, id string) (*PortObject, error) {
+	return f.getPortObjectByID(ctx, "TCP", id, 0)
 }
 
 // GetUDPPortObjectByID Get a udp port by ID
 func (f *FTD) GetUDPPortObjectByID(id string) (*PortObject, error) {
-	return f.getPortObjectByID("UDP", id, 0)
+	return f.GetUDPPortObjectByIDContext(context.Background(), id)
 }
 
-func (f *FTD) getPortObjectBy(protocol, filterString string) ([]*PortObject, error) {
-	var err error
-	var endpoint string
+// GetUDPPortObjectByIDContext Same as GetUDPPortObjectByID, ctx cancels the requests
+func (f *FTD) GetUDPPortObjectByIDContext(ctx context.Context, id string) (*PortObject, error) {
+	return f.getPortObjectByID(ctx, "UDP", id, 0)
+}
 
-	switch protocol {
-	case "TCP":
-		endpoint = apiTCPPortObjectsEndpoint
-	case "UDP":
-		endpoint = apiUDPPortObjectsEndpoint
-	}
+func (f *FTD) getPortObjectBy(ctx context.Context, protocol, filterString string) ([]*PortObject, error) {
+	var err error
+	var retval []*PortObject
 
 	filter := make(map[string]string)
 	filter["filter"] = filterString
 
-	data, err := f.Get(endpoint, filter)
+	err = f.iterPortObjects(ctx, protocol, filter, 0, func(p *PortObject) error {
+		retval = append(retval, p)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var v struct {
-		Items []*PortObject `json:"items"`
-	}
-
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
-		return nil, err
-	}
-
-	return v.Items, nil
+	return retval, nil
 }
 
-func (f *FTD) createPortObject(p *PortObject, duplicateAction int) error {
+func (f *FTD) createPortObject(ctx context.Context, p *PortObject, duplicateAction int) error {
 	var err error
-	var protocol string
 	var endpoint string
 
 	switch p.Type {
 	case TypeTCPPortObject:
-		protocol = "TCP"
 		endpoint = apiTCPPortObjectsEndpoint
 	case TypeUDPPortObject:
-		protocol = "UDP"
 		endpoint = apiUDPPortObjectsEndpoint
 	}
 
-	_, err = f.Post(endpoint, p)
+	err = p.Validate()
 	if err != nil {
-		ftdErr := err.(*FTDError)
+		return err
+	}
 
-		if len(ftdErr.Message) > 0 && (ftdErr.Message[0].Code == "duplicateName" || ftdErr.Message[0].Code == "newInstanceWithDuplicateId") {
+	_, err = f.PostContext(ctx, endpoint, p)
+	if err != nil {
+		if IsDuplicate(err) {
 			if f.debug {
-				glog.Errorf("This is a duplicate\n")
+				f.logger.Errorf("This is a duplicate\n")
 			}
 			if duplicateAction == DuplicateActionError {
 				return err
 			}
 		} else {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
 	}
 
-	query := fmt.Sprintf("name:%s", p.Name)
-	obj, err := f.getPortObjectBy(protocol, query)
+	o := new(PortObject)
+	err = f.findCreated(ctx, endpoint, p.Name, o)
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
-		return err
-	}
-
-	var o *PortObject
-	if len(obj) == 1 {
-		o = obj[0]
-	} else {
-		if f.debug {
-			glog.Errorf("Error: length of object is not 1\n")
-		}
 		return err
 	}
 
@@ -206,10 +246,10 @@ func (f *FTD) createPortObject(p *PortObject, duplicateAction int) error {
 	case DuplicateActionReplace:
 		o.Port = p.Port
 
-		err = f.UpdatePortObject(o)
+		err = f.UpdatePortObjectContext(ctx, o)
 		if err != nil {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
@@ -225,7 +265,7 @@ func (f *FTD) createPortObject(p *PortObject, duplicateAction int) error {
 			err = json.Unmarshal(data, &p)
 			if err != nil {
 				if f.debug {
-					glog.Errorf("Error: %s\n", err)
+					f.logger.Errorf("Error: %s\n", err)
 				}
 				return err
 			}
@@ -237,7 +277,7 @@ func (f *FTD) createPortObject(p *PortObject, duplicateAction int) error {
 			obj, err := f.getPortBy(protocol, query)
 			if err != nil {
 				if f.debug {
-					glog.Errorf("Error: %s\n", err)
+					f.logger.Errorf("Error: %s\n", err)
 				}
 				return err
 			}
@@ -249,7 +289,7 @@ func (f *FTD) createPortObject(p *PortObject, duplicateAction int) error {
 				err = f.UpdatePort(o)
 				if err != nil {
 					if f.debug {
-						glog.Errorf("Error: %s\n", err)
+						f.logger.Errorf("Error: %s\n", err)
 					}
 					return err
 				}
@@ -267,18 +307,33 @@ func (f *FTD) createPortObject(p *PortObject, duplicateAction int) error {
 
 // CreateTCPPortObject Creates a new TCP port
 func (f *FTD) CreateTCPPortObject(p *PortObject, duplicateAction int) error {
+	return f.CreateTCPPortObjectContext(context.Background(), p, duplicateAction)
+}
+
+// CreateTCPPortObjectContext Same as CreateTCPPortObject, ctx cancels the requests
+func (f *FTD) CreateTCPPortObjectContext(ctx context.Context, p *PortObject, duplicateAction int) error {
 	p.Type = TypeTCPPortObject
-	return f.createPortObject(p, duplicateAction)
+	return f.createPortObject(ctx, p, duplicateAction)
 }
 
 // CreateUDPPortObject Creates a new UDP port
 func (f *FTD) CreateUDPPortObject(p *PortObject, duplicateAction int) error {
+	return f.CreateUDPPortObjectContext(context.Background(), p, duplicateAction)
+}
+
+// CreateUDPPortObjectContext Same as CreateUDPPortObject, ctx cancels the requests
+func (f *FTD) CreateUDPPortObjectContext(ctx context.Context, p *PortObject, duplicateAction int) error {
 	p.Type = TypeUDPPortObject
-	return f.createPortObject(p, duplicateAction)
+	return f.createPortObject(ctx, p, duplicateAction)
 }
 
 // DeletePortObject Delete a port
 func (f *FTD) DeletePortObject(p *PortObject) error {
+	return f.DeletePortObjectContext(context.Background(), p)
+}
+
+// DeletePortObjectContext Same as DeletePortObject, ctx cancels the requests
+func (f *FTD) DeletePortObjectContext(ctx context.Context, p *PortObject) error {
 	var err error
 	var endpoint string
 
@@ -289,10 +344,10 @@ func (f *FTD) DeletePortObject(p *PortObject) error {
 		endpoint = fmt.Sprintf("%s/%s", apiUDPPortObjectsEndpoint, p.ID)
 	}
 
-	err = f.Delete(endpoint)
+	err = f.DeleteContext(ctx, endpoint)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -302,9 +357,19 @@ func (f *FTD) DeletePortObject(p *PortObject) error {
 
 // UpdatePortObject Updates a port
 func (f *FTD) UpdatePortObject(p *PortObject) error {
+	return f.UpdatePortObjectContext(context.Background(), p)
+}
+
+// UpdatePortObjectContext Same as UpdatePortObject, ctx cancels the requests
+func (f *FTD) UpdatePortObjectContext(ctx context.Context, p *PortObject) error {
 	var err error
 	var endpoint string
 
+	err = p.Validate()
+	if err != nil {
+		return err
+	}
+
 	switch p.Type {
 	case TypeTCPPortObject:
 		endpoint = fmt.Sprintf("%s/%s", apiTCPPortObjectsEndpoint, p.ID)
@@ -312,10 +377,10 @@ func (f *FTD) UpdatePortObject(p *PortObject) error {
 		endpoint = fmt.Sprintf("%s/%s", apiUDPPortObjectsEndpoint, p.ID)
 	}
 
-	data, err := f.Put(endpoint, p)
+	data, err := f.PutContext(ctx, endpoint, p)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -323,7 +388,7 @@ func (f *FTD) UpdatePortObject(p *PortObject) error {
 	err = json.Unmarshal(data, &p)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
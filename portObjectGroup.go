@@ -1,11 +1,9 @@
 package goftd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-
-	"github.com/golang/glog"
 )
 
 // PortObjectGroup Port Object Group
@@ -29,103 +27,79 @@ func (p *PortObjectGroup) Reference() *ReferenceObject {
 	return &r
 }
 
-// GetPortObjectGroups Get all the port object groups within the limit specified
+// GetPortObjectGroups Get all the port object groups within the limit specified, a limit of 0 returns all of them
 func (f *FTD) GetPortObjectGroups(limit int) ([]*PortObjectGroup, error) {
-	var err error
-
-	filter := make(map[string]string)
-	filter["limit"] = strconv.Itoa(limit)
-
-	endpoint := apiPortObjectGroupsEndpoint
-	data, err := f.Get(endpoint, filter)
-	if err != nil {
-		return nil, err
-	}
+	return f.GetPortObjectGroupsContext(context.Background(), limit)
+}
 
-	var v struct {
-		Items []*PortObjectGroup `json:"items"`
-		//Paging *Paging       `json:"paging"`
-	}
+// GetPortObjectGroupsContext Same as GetPortObjectGroups, ctx cancels the requests
+func (f *FTD) GetPortObjectGroupsContext(ctx context.Context, limit int) ([]*PortObjectGroup, error) {
+	var err error
+	var retval []*PortObjectGroup
 
-	err = json.Unmarshal(data, &v)
+	err = f.iterPortObjectGroups(ctx, nil, limit, func(g *PortObjectGroup) error {
+		retval = append(retval, g)
+		return nil
+	})
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
 		return nil, err
 	}
 
-	return v.Items, nil
+	return retval, nil
 }
 
-func (f *FTD) getPortObjectGroupBy(filterString string) ([]*PortObjectGroup, error) {
-	var err error
-
-	filter := make(map[string]string)
-	filter["filter"] = filterString
-
-	endpoint := apiPortObjectGroupsEndpoint
-	data, err := f.Get(endpoint, filter)
-	if err != nil {
-		return nil, err
-	}
+// IterPortObjectGroups Calls fn for every port object group, fetching one page at a time
+func (f *FTD) IterPortObjectGroups(ctx context.Context, fn func(*PortObjectGroup) error) error {
+	return f.iterPortObjectGroups(ctx, nil, 0, fn)
+}
 
-	var v struct {
-		Items []*PortObjectGroup `json:"items"`
-	}
+func (f *FTD) iterPortObjectGroups(ctx context.Context, query map[string]string, limit int, fn func(*PortObjectGroup) error) error {
+	return f.iterate(ctx, apiPortObjectGroupsEndpoint, query, limit, func(item json.RawMessage) error {
+		var g *PortObjectGroup
 
-	err = json.Unmarshal(data, &v)
-	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+		err := json.Unmarshal(item, &g)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	return v.Items, nil
+		return fn(g)
+	})
 }
 
 // CreatePortObjectGroup Create a new port object group
 func (f *FTD) CreatePortObjectGroup(g *PortObjectGroup, duplicateAction int) error {
+	return f.CreatePortObjectGroupContext(context.Background(), g, duplicateAction)
+}
+
+// CreatePortObjectGroupContext Same as CreatePortObjectGroup, ctx cancels the requests
+func (f *FTD) CreatePortObjectGroupContext(ctx context.Context, g *PortObjectGroup, duplicateAction int) error {
 	var err error
 
 	g.Type = "portobjectgroup"
 	endpoint := apiPortObjectGroupsEndpoint
-	_, err = f.Post(endpoint, g)
+	_, err = f.PostContext(ctx, endpoint, g)
 	if err != nil {
-		ftdErr := err.(*FTDError)
-		//spew.Dump(ftdErr)
-		if len(ftdErr.Message) > 0 && (ftdErr.Message[0].Code == "duplicateName" || ftdErr.Message[0].Code == "newInstanceWithDuplicateId") {
+		if IsDuplicate(err) {
 			if f.debug {
-				glog.Warningf("This is a duplicate\n")
+				f.logger.Warningf("This is a duplicate\n")
 			}
 			if duplicateAction == DuplicateActionError {
 				return err
 			}
 		} else {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
 	}
 
-	query := fmt.Sprintf("name:%s", g.Name)
-	obj, err := f.getPortObjectGroupBy(query)
+	o := new(PortObjectGroup)
+	err = f.findCreated(ctx, endpoint, g.Name, o)
 	if err != nil {
-		if f.debug {
-			glog.Errorf("Error: %s\n", err)
-		}
-		return err
-	}
-
-	var o *PortObjectGroup
-	if len(obj) == 1 {
-		o = obj[0]
-	} else {
-		if f.debug {
-			glog.Errorf("Error: length of object is not 1\n")
-		}
 		return err
 	}
 
@@ -133,10 +107,10 @@ func (f *FTD) CreatePortObjectGroup(g *PortObjectGroup, duplicateAction int) err
 	case DuplicateActionReplace:
 		o.Objects = g.Objects
 
-		err = f.UpdatePortObjectGroup(o)
+		err = f.UpdatePortObjectGroupContext(ctx, o)
 		if err != nil {
 			if f.debug {
-				glog.Errorf("Error: %s\n", err)
+				f.logger.Errorf("Error: %s\n", err)
 			}
 			return err
 		}
@@ -148,13 +122,18 @@ func (f *FTD) CreatePortObjectGroup(g *PortObjectGroup, duplicateAction int) err
 
 // DeletePortObjectGroup Delete a port object group
 func (f *FTD) DeletePortObjectGroup(g *PortObjectGroup) error {
+	return f.DeletePortObjectGroupContext(context.Background(), g)
+}
+
+// DeletePortObjectGroupContext Same as DeletePortObjectGroup, ctx cancels the requests
+func (f *FTD) DeletePortObjectGroupContext(ctx context.Context, g *PortObjectGroup) error {
 	var err error
 
 	endpoint := fmt.Sprintf("%s/%s", apiPortObjectGroupsEndpoint, g.ID)
-	err = f.Delete(endpoint)
+	err = f.DeleteContext(ctx, endpoint)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -164,13 +143,18 @@ func (f *FTD) DeletePortObjectGroup(g *PortObjectGroup) error {
 
 // UpdatePortObjectGroup Updates a port object group
 func (f *FTD) UpdatePortObjectGroup(g *PortObjectGroup) error {
+	return f.UpdatePortObjectGroupContext(context.Background(), g)
+}
+
+// UpdatePortObjectGroupContext Same as UpdatePortObjectGroup, ctx cancels the requests
+func (f *FTD) UpdatePortObjectGroupContext(ctx context.Context, g *PortObjectGroup) error {
 	var err error
 
 	endpoint := fmt.Sprintf("%s/%s", apiPortObjectGroupsEndpoint, g.ID)
-	data, err := f.Put(endpoint, g)
+	data, err := f.PutContext(ctx, endpoint, g)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -178,7 +162,7 @@ func (f *FTD) UpdatePortObjectGroup(g *PortObjectGroup) error {
 	err = json.Unmarshal(data, &g)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -186,24 +170,31 @@ func (f *FTD) UpdatePortObjectGroup(g *PortObjectGroup) error {
 	return nil
 }
 
-// AddToPortObjectGroup Add a Port to an Object Group
-func (f *FTD) AddToPortObjectGroup(g *PortObjectGroup, p *PortObject) error {
+// AddToPortObjectGroup Add a TCP, UDP or ICMP port to an Object Group
+func (f *FTD) AddToPortObjectGroup(g *PortObjectGroup, p ServiceObject) error {
+	return f.AddToPortObjectGroupContext(context.Background(), g, p)
+}
+
+// AddToPortObjectGroupContext Same as AddToPortObjectGroup, ctx cancels the requests
+func (f *FTD) AddToPortObjectGroupContext(ctx context.Context, g *PortObjectGroup, p ServiceObject) error {
 	var err error
+
+	r := p.Reference()
 	for k := range g.Objects {
-		if g.Objects[k].ID == p.ID {
+		if g.Objects[k].ID == r.ID {
 			if f.debug {
-				glog.Errorf("object already in object group\n")
-				return fmt.Errorf("object already in object group")
+				f.logger.Errorf("object already in object group\n")
 			}
+			return fmt.Errorf("object already in object group")
 		}
 	}
 
-	g.Objects = append(g.Objects, p.Reference())
+	g.Objects = append(g.Objects, r)
 
-	err = f.UpdatePortObjectGroup(g)
+	err = f.UpdatePortObjectGroupContext(ctx, g)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -211,20 +202,27 @@ func (f *FTD) AddToPortObjectGroup(g *PortObjectGroup, p *PortObject) error {
 	return nil
 }
 
-// DeleteFromPortObjectGroup Deletes a Port from an Object Group
-func (f *FTD) DeleteFromPortObjectGroup(g *PortObjectGroup, p *PortObject) error {
+// DeleteFromPortObjectGroup Deletes a TCP, UDP or ICMP port from an Object Group
+func (f *FTD) DeleteFromPortObjectGroup(g *PortObjectGroup, p ServiceObject) error {
+	return f.DeleteFromPortObjectGroupContext(context.Background(), g, p)
+}
+
+// DeleteFromPortObjectGroupContext Same as DeleteFromPortObjectGroup, ctx cancels the requests
+func (f *FTD) DeleteFromPortObjectGroupContext(ctx context.Context, g *PortObjectGroup, p ServiceObject) error {
 	var err error
+
+	r := p.Reference()
 	for k := range g.Objects {
-		if g.Objects[k].ID == p.ID {
+		if g.Objects[k].ID == r.ID {
 			g.Objects = append(g.Objects[:k], g.Objects[k+1:]...)
 			break
 		}
 	}
 
-	err = f.UpdatePortObjectGroup(g)
+	err = f.UpdatePortObjectGroupContext(ctx, g)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return err
 	}
@@ -1,6 +1,7 @@
 package goftd
 
 import (
+	"context"
 	"fmt"
 	"testing"
 )
@@ -49,7 +50,7 @@ func TestTCPPort(t *testing.T) {
 
 	t.Logf("object p1: %+v\n", p1)
 
-	p2, err := ftd.getPortObjectBy("TCP", fmt.Sprintf("name:%s", p1.Name))
+	p2, err := ftd.getPortObjectBy(context.Background(), "TCP", fmt.Sprintf("name:%s", p1.Name))
 	if err != nil {
 		t.Errorf("error: %s\n", err)
 		return
@@ -100,7 +101,7 @@ func TestUDPPort(t *testing.T) {
 
 	t.Logf("object p1: %+v\n", p1)
 
-	p2, err := ftd.getPortObjectBy("UDP", fmt.Sprintf("name:%s", p1.Name))
+	p2, err := ftd.getPortObjectBy(context.Background(), "UDP", fmt.Sprintf("name:%s", p1.Name))
 	if err != nil {
 		t.Errorf("error: %s\n", err)
 		return
@@ -124,3 +125,28 @@ func TestUDPPort(t *testing.T) {
 	}
 
 }
+
+func TestCreatePortObjectPrefixName(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	for _, port := range []string{"8080", "808"} {
+		p := new(PortObject)
+		p.Name = "testPrefixPort" + port
+		p.Port = port
+
+		err = ftd.CreateTCPPortObject(p, DuplicateActionError)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+		defer ftd.DeletePortObject(p)
+
+		if p.Port != port {
+			t.Errorf("expecting port %s, got %s\n", port, p.Port)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ProtocolObject Represents an IP protocol, e.g. 47 for GRE
+type ProtocolObject struct {
+	ReferenceObject
+	Description     string `json:"description,omitempty"`
+	Protocol        string `json:"protocol,omitempty"`
+	IsSystemDefined bool   `json:"isSystemDefined,omitempty"`
+	Links           *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (p *ProtocolObject) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      p.ID,
+		Name:    p.Name,
+		Version: p.Version,
+		Type:    p.Type,
+	}
+
+	return &r
+}
+
+// GetProtocolObjects Get a list of protocols, a limit of 0 returns all of them
+func (f *FTD) GetProtocolObjects(limit int) ([]*ProtocolObject, error) {
+	return f.GetProtocolObjectsContext(context.Background(), limit)
+}
+
+// GetProtocolObjectsContext Same as GetProtocolObjects, ctx cancels the requests
+func (f *FTD) GetProtocolObjectsContext(ctx context.Context, limit int) ([]*ProtocolObject, error) {
+	return f.getProtocolObjects(ctx, nil, limit)
+}
+
+// IterProtocolObjects Calls fn for every protocol, fetching one page at a time
+func (f *FTD) IterProtocolObjects(ctx context.Context, fn func(*ProtocolObject) error) error {
+	return f.iterProtocolObjects(ctx, nil, 0, fn)
+}
+
+func (f *FTD) iterProtocolObjects(ctx context.Context, query map[string]string, limit int, fn func(*ProtocolObject) error) error {
+	return f.iterate(ctx, apiProtocolObjectsEndpoint, query, limit, func(item json.RawMessage) error {
+		var p *ProtocolObject
+
+		err := json.Unmarshal(item, &p)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(p)
+	})
+}
+
+func (f *FTD) getProtocolObjects(ctx context.Context, query map[string]string, limit int) ([]*ProtocolObject, error) {
+	var err error
+	var retval []*ProtocolObject
+
+	err = f.iterProtocolObjects(ctx, query, limit, func(p *ProtocolObject) error {
+		retval = append(retval, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// CreateProtocolObject Creates a new protocol
+func (f *FTD) CreateProtocolObject(p *ProtocolObject, duplicateAction int) error {
+	return f.CreateProtocolObjectContext(context.Background(), p, duplicateAction)
+}
+
+// CreateProtocolObjectContext Same as CreateProtocolObject, ctx cancels the requests
+func (f *FTD) CreateProtocolObjectContext(ctx context.Context, p *ProtocolObject, duplicateAction int) error {
+	var err error
+
+	p.Type = TypeProtocolObject
+
+	_, err = f.PostContext(ctx, apiProtocolObjectsEndpoint, p)
+	if err != nil {
+		if IsDuplicate(err) {
+			if f.debug {
+				f.logger.Warningf("This is a duplicate\n")
+			}
+			if duplicateAction == DuplicateActionError {
+				return err
+			}
+		} else {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	o := new(ProtocolObject)
+	err = f.findCreated(ctx, apiProtocolObjectsEndpoint, p.Name, o)
+	if err != nil {
+		return err
+	}
+
+	switch duplicateAction {
+	case DuplicateActionReplace:
+		o.Protocol = p.Protocol
+
+		err = f.UpdateProtocolObjectContext(ctx, o)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	*p = *o
+	return nil
+}
+
+// UpdateProtocolObject Updates a protocol
+func (f *FTD) UpdateProtocolObject(p *ProtocolObject) error {
+	return f.UpdateProtocolObjectContext(context.Background(), p)
+}
+
+// UpdateProtocolObjectContext Same as UpdateProtocolObject, ctx cancels the requests
+func (f *FTD) UpdateProtocolObjectContext(ctx context.Context, p *ProtocolObject) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiProtocolObjectsEndpoint, p.ID)
+	data, err := f.PutContext(ctx, endpoint, p)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &p)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteProtocolObject Delete a protocol
+func (f *FTD) DeleteProtocolObject(p *ProtocolObject) error {
+	return f.DeleteProtocolObjectContext(context.Background(), p)
+}
+
+// DeleteProtocolObjectContext Same as DeleteProtocolObject, ctx cancels the requests
+func (f *FTD) DeleteProtocolObjectContext(ctx context.Context, p *ProtocolObject) error {
+	var err error
+
+	err = f.DeleteContext(ctx, fmt.Sprintf("%s/%s", apiProtocolObjectsEndpoint, p.ID))
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestProtocol(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	// the second name is a prefix of the first, the lookup after the creation must not mix them up
+	var created []*ProtocolObject
+	for _, name := range []string{"testProtocolGRE10", "testProtocolGRE1"} {
+		p := new(ProtocolObject)
+		p.Name = name
+		p.Protocol = "47"
+
+		err = ftd.CreateProtocolObject(p, DuplicateActionError)
+		if err != nil {
+			t.Errorf("%s: error: %s\n", name, err)
+			break
+		}
+		created = append(created, p)
+
+		if p.ID == "" || p.Version == "" || p.Name != name || p.Type != TypeProtocolObject {
+			t.Errorf("%s: unexpected object %+v\n", name, p)
+		}
+	}
+
+	for _, p1 := range created {
+		p2 := new(ProtocolObject)
+		p2.Name = p1.Name
+		p2.Protocol = "50"
+		err = ftd.CreateProtocolObject(p2, DuplicateActionReplace)
+		if err != nil {
+			t.Errorf("%s: error: %s\n", p1.Name, err)
+		} else if p2.ID != p1.ID || p2.Protocol != "50" {
+			t.Errorf("%s: expecting %s replaced with protocol 50, got %+v\n", p1.Name, p1.ID, p2)
+		}
+
+		err = ftd.DeleteProtocolObject(p1)
+		if err != nil {
+			t.Errorf("%s: error: %s\n", p1.Name, err)
+		}
+	}
+
+	protocols, err := ftd.GetProtocolObjects(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	for _, p := range protocols {
+		for _, p1 := range created {
+			if p.ID == p1.ID {
+				t.Errorf("%s: still there after its deletion\n", p1.Name)
+			}
+		}
+	}
+}
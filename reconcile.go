@@ -0,0 +1,654 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DesiredState Objects and rules the device should have. References between them are resolved by name,
+// a reference without ID points to the object of the same name, e.g. &ReferenceObject{Name: "web01"}.
+// Port objects must have their Type set to TypeTCPPortObject or TypeUDPPortObject.
+// The fields left empty are not compared with the device, which may fill them with its defaults.
+type DesiredState struct {
+	NetworkObjects      []*NetworkObject
+	NetworkObjectGroups []*NetworkObjectGroup
+	PortObjects         []*PortObject
+	PortObjectGroups    []*PortObjectGroup
+	AccessRules         []*AccessRule
+	// AccessPolicy ID of the policy of the access rules, "default" when empty
+	AccessPolicy string
+}
+
+// ReconcileOptions Options of Reconcile
+type ReconcileOptions struct {
+	// DryRun returns the plan without changing the device
+	DryRun bool
+	// Prune deletes the objects and rules of the device missing from the desired state,
+	// system defined objects and the objects the desired state still references are never deleted
+	Prune bool
+}
+
+// PlanStep A single change of a plan
+type PlanStep struct {
+	Action string
+	Kind   string
+	Name   string
+	// Live object on the device, nil for a create until it is applied
+	Live interface{}
+	// Desired object, nil for a delete
+	Desired interface{}
+	// Applied is true once the step was applied to the device
+	Applied bool
+}
+
+func (s *PlanStep) String() string {
+	return fmt.Sprintf("%s %s %s", s.Action, s.Kind, s.Name)
+}
+
+// Plan Ordered changes converging the device to a desired state: creates and updates
+// with their dependencies first, then deletes in reverse order
+type Plan struct {
+	Steps []*PlanStep
+}
+
+// Empty Returns true when the device already matches the desired state
+func (p *Plan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+func (p *Plan) String() string {
+	var lines []string
+	for _, s := range p.Steps {
+		lines = append(lines, s.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// referencer An object that can be referenced
+type referencer interface {
+	Reference() *ReferenceObject
+}
+
+// reconcileItem A named object of a state
+type reconcileItem struct {
+	kind   string
+	name   string
+	obj    interface{}
+	system bool
+}
+
+// reconcileOrder Order in which the kinds are created, dependencies first
+var reconcileOrder = map[string]int{
+	"networkobject":      0,
+	"networkobjectgroup": 1,
+	TypeTCPPortObject:    2,
+	TypeUDPPortObject:    2,
+	"portobjectgroup":    3,
+	"accessrule":         4,
+}
+
+// Reconcile Diffs desired against the device and applies the resulting plan, unless DryRun is set.
+// New access rules are appended to the policy. When an error occurs the plan is returned with
+// the steps applied so far marked as such.
+func (f *FTD) Reconcile(ctx context.Context, desired *DesiredState, opts ReconcileOptions) (*Plan, error) {
+	live, err := f.liveState(ctx, desired.policy())
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	plan, err := planReconcile(desired, live, opts.Prune)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	err = f.applyPlan(ctx, plan, live, desired.policy())
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return plan, err
+	}
+
+	return plan, nil
+}
+
+func (d *DesiredState) policy() string {
+	if d.AccessPolicy == "" {
+		return "default"
+	}
+
+	return d.AccessPolicy
+}
+
+// items Returns the objects of the state, network object groups ordered by nesting
+func (d *DesiredState) items() ([]*reconcileItem, error) {
+	var retval []*reconcileItem
+
+	for _, n := range d.NetworkObjects {
+		retval = append(retval, &reconcileItem{kind: "networkobject", name: n.Name, obj: n, system: n.IsSystemDefined})
+	}
+
+	groups, err := sortNetworkObjectGroups(d.NetworkObjectGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		retval = append(retval, &reconcileItem{kind: "networkobjectgroup", name: g.Name, obj: g, system: g.IsSystemDefined})
+	}
+
+	for _, p := range d.PortObjects {
+		if p.Type != TypeTCPPortObject && p.Type != TypeUDPPortObject {
+			return nil, fmt.Errorf("port object %s: unknown type %q", p.Name, p.Type)
+		}
+		retval = append(retval, &reconcileItem{kind: p.Type, name: p.Name, obj: p, system: p.IsSystemDefined})
+	}
+
+	for _, g := range d.PortObjectGroups {
+		retval = append(retval, &reconcileItem{kind: "portobjectgroup", name: g.Name, obj: g, system: g.IsSystemDefined})
+	}
+
+	for _, a := range d.AccessRules {
+		retval = append(retval, &reconcileItem{kind: "accessrule", name: a.Name, obj: a})
+	}
+
+	return retval, nil
+}
+
+// sortNetworkObjectGroups Orders groups so that nested groups come before the groups holding them
+func sortNetworkObjectGroups(groups []*NetworkObjectGroup) ([]*NetworkObjectGroup, error) {
+	var retval []*NetworkObjectGroup
+
+	byName := make(map[string]*NetworkObjectGroup)
+	for _, g := range groups {
+		byName[g.Name] = g
+	}
+
+	// 1 while visiting, 2 once added
+	state := make(map[string]int)
+
+	var visit func(g *NetworkObjectGroup) error
+	visit = func(g *NetworkObjectGroup) error {
+		switch state[g.Name] {
+		case 1:
+			return fmt.Errorf("network object group %s: %w", g.Name, ErrCycle)
+		case 2:
+			return nil
+		}
+
+		state[g.Name] = 1
+		for _, r := range g.Objects {
+			if n, ok := byName[r.Name]; ok && r.Type != "networkobject" {
+				err := visit(n)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		state[g.Name] = 2
+
+		retval = append(retval, g)
+		return nil
+	}
+
+	for _, g := range groups {
+		err := visit(g)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return retval, nil
+}
+
+// liveState Returns the objects and rules of the device
+func (f *FTD) liveState(ctx context.Context, policy string) (*DesiredState, error) {
+	var err error
+
+	live := &DesiredState{AccessPolicy: policy}
+
+	live.NetworkObjects, err = f.GetNetworkObjectsContext(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	live.NetworkObjectGroups, err = f.GetNetworkObjectGroupsContext(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, protocol := range []string{"TCP", "UDP"} {
+		p, err := f.getPortObjects(ctx, protocol, 0)
+		if err != nil {
+			return nil, err
+		}
+		live.PortObjects = append(live.PortObjects, p...)
+	}
+
+	live.PortObjectGroups, err = f.GetPortObjectGroupsContext(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	live.AccessRules, err = f.GetAccessRulesContext(ctx, policy, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return live, nil
+}
+
+// planReconcile Returns the steps turning live into desired
+func planReconcile(desired, live *DesiredState, prune bool) (*Plan, error) {
+	want, err := desired.items()
+	if err != nil {
+		return nil, err
+	}
+
+	have, err := live.items()
+	if err != nil {
+		return nil, err
+	}
+
+	haveByKey := make(map[string]*reconcileItem)
+	for _, i := range have {
+		haveByKey[i.kind+"/"+i.name] = i
+	}
+
+	err = checkReferences(want, have)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(Plan)
+	wanted := make(map[string]bool)
+
+	for _, w := range want {
+		key := w.kind + "/" + w.name
+		if wanted[key] {
+			return nil, fmt.Errorf("%s %s is listed twice", w.kind, w.name)
+		}
+		wanted[key] = true
+
+		h, ok := haveByKey[key]
+		if !ok {
+			plan.Steps = append(plan.Steps, &PlanStep{Action: PlanActionCreate, Kind: w.kind, Name: w.name, Desired: w.obj})
+			continue
+		}
+
+		if h.system {
+			continue
+		}
+
+		same, err := sameObject(w.obj, h.obj)
+		if err != nil {
+			return nil, err
+		}
+
+		if !same {
+			plan.Steps = append(plan.Steps, &PlanStep{Action: PlanActionUpdate, Kind: w.kind, Name: w.name, Live: h.obj, Desired: w.obj})
+		}
+	}
+
+	sort.SliceStable(plan.Steps, func(i, j int) bool {
+		return reconcileOrder[plan.Steps[i].Kind] < reconcileOrder[plan.Steps[j].Kind]
+	})
+
+	if !prune {
+		return plan, nil
+	}
+
+	kept := referenced(want, have, wanted)
+
+	// Walk the live objects backwards so that a group is deleted before the groups it nests
+	var deletes []*PlanStep
+	for i := len(have) - 1; i >= 0; i-- {
+		h := have[i]
+		if h.system || wanted[h.kind+"/"+h.name] || kept[h] {
+			continue
+		}
+		deletes = append(deletes, &PlanStep{Action: PlanActionDelete, Kind: h.kind, Name: h.name, Live: h.obj})
+	}
+
+	sort.SliceStable(deletes, func(i, j int) bool {
+		return reconcileOrder[deletes[i].Kind] > reconcileOrder[deletes[j].Kind]
+	})
+
+	plan.Steps = append(plan.Steps, deletes...)
+
+	return plan, nil
+}
+
+// referenced Returns the live objects missing from the desired state that it still references, by name or ID,
+// directly or through another of these objects, e.g. the members of a live group used by a desired rule
+func referenced(want, have []*reconcileItem, wanted map[string]bool) map[*reconcileItem]bool {
+	byRef := make(map[string]*reconcileItem)
+	for _, h := range have {
+		if wanted[h.kind+"/"+h.name] {
+			continue
+		}
+		byRef[namespace(h.kind)+"/"+h.name] = h
+		byRef["id/"+h.obj.(referencer).Reference().ID] = h
+	}
+
+	retval := make(map[*reconcileItem]bool)
+	queue := append([]*reconcileItem(nil), want...)
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		for ns, refs := range references(item.obj) {
+			for _, r := range refs {
+				key := ns + "/" + r.Name
+				if r.ID != "" {
+					key = "id/" + r.ID
+				}
+
+				h, ok := byRef[key]
+				if ok && !retval[h] {
+					retval[h] = true
+					queue = append(queue, h)
+				}
+			}
+		}
+	}
+
+	return retval
+}
+
+// references Returns the references of an object by namespace, network or port
+func references(obj interface{}) map[string][]*ReferenceObject {
+	retval := make(map[string][]*ReferenceObject)
+
+	switch o := obj.(type) {
+	case *NetworkObjectGroup:
+		retval["network"] = o.Objects
+	case *PortObjectGroup:
+		retval["port"] = o.Objects
+	case *AccessRule:
+		retval["network"] = append(append([]*ReferenceObject(nil), o.SourceNetworks...), o.DestinationNetworks...)
+		retval["port"] = append(append([]*ReferenceObject(nil), o.SourcePorts...), o.DestinationPorts...)
+	}
+
+	return retval
+}
+
+// namespace Returns the namespace in which the objects of kind are referenced
+func namespace(kind string) string {
+	switch kind {
+	case "networkobject", "networkobjectgroup":
+		return "network"
+	case TypeTCPPortObject, TypeUDPPortObject, "portobjectgroup":
+		return "port"
+	}
+
+	return ""
+}
+
+// checkReferences Checks every reference without ID names an object of the device or of the desired state
+func checkReferences(want, have []*reconcileItem) error {
+	names := make(map[string]bool)
+	for _, i := range append(append([]*reconcileItem(nil), want...), have...) {
+		names[namespace(i.kind)+"/"+i.name] = true
+	}
+
+	for _, w := range want {
+		for ns, refs := range references(w.obj) {
+			for _, r := range refs {
+				if r.ID == "" && !names[ns+"/"+r.Name] {
+					return fmt.Errorf("%s %s: unresolved reference %s: %w", w.kind, w.name, r.Name, ErrNotFound)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// sameObject Returns true when live has every field set in desired, with the same value. The fields desired
+// leaves empty are not compared, the device fills them with its defaults, e.g. the eventLogAction of a rule.
+// IDs, versions, links and the order of the references are ignored.
+func sameObject(desired, live interface{}) (bool, error) {
+	d, err := canonical(desired)
+	if err != nil {
+		return false, err
+	}
+
+	l, err := canonical(live)
+	if err != nil {
+		return false, err
+	}
+
+	return covers(l, d), nil
+}
+
+// canonical Returns the decoded JSON of v without the fields set by the device
+func canonical(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m interface{}
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return strip(m), nil
+}
+
+// strip Removes the fields set by the device from a decoded JSON value
+func strip(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, k := range []string{"id", "version", "links", "type", "ruleId", "isSystemDefined"} {
+			delete(t, k)
+		}
+		for k := range t {
+			t[k] = strip(t[k])
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = strip(t[i])
+		}
+	}
+
+	return v
+}
+
+// covers Returns true when have holds every field of want with the same value, null fields of want are ignored.
+// Arrays must have the same length and each item of want must be covered by a distinct item of have, in any order.
+func covers(have, want interface{}) bool {
+	switch w := want.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		h, ok := have.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for k, v := range w {
+			if v == nil {
+				continue
+			}
+			if !covers(h[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		h, ok := have.([]interface{})
+		if !ok || len(h) != len(w) {
+			return false
+		}
+
+		used := make([]bool, len(h))
+		for _, v := range w {
+			found := false
+			for i := range h {
+				if !used[i] && covers(h[i], v) {
+					used[i] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	return have == want
+}
+
+// applyPlan Applies the steps of a plan in order
+func (f *FTD) applyPlan(ctx context.Context, plan *Plan, live *DesiredState, policy string) error {
+	have, err := live.items()
+	if err != nil {
+		return err
+	}
+
+	resolver := make(map[string]*ReferenceObject)
+	for _, h := range have {
+		resolver[namespace(h.kind)+"/"+h.name] = h.obj.(referencer).Reference()
+	}
+
+	for _, s := range plan.Steps {
+		o, err := f.applyStep(ctx, s, resolver, policy)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s, err)
+		}
+		s.Applied = true
+
+		if o != nil {
+			s.Live = o
+			resolver[namespace(s.Kind)+"/"+s.Name] = o.(referencer).Reference()
+		}
+	}
+
+	return nil
+}
+
+// applyStep Applies a single step to a copy of the desired object, with its references resolved,
+// and returns that copy once created or updated
+func (f *FTD) applyStep(ctx context.Context, s *PlanStep, resolver map[string]*ReferenceObject, policy string) (interface{}, error) {
+	if s.Action == PlanActionDelete {
+		switch o := s.Live.(type) {
+		case *NetworkObject:
+			return nil, f.DeleteNetworkObjectContext(ctx, o)
+		case *NetworkObjectGroup:
+			return nil, f.DeleteNetworkObjectGroupContext(ctx, o)
+		case *PortObject:
+			return nil, f.DeletePortObjectContext(ctx, o)
+		case *PortObjectGroup:
+			return nil, f.DeletePortObjectGroupContext(ctx, o)
+		case *AccessRule:
+			return nil, f.DeleteAccessRuleContext(ctx, o)
+		}
+		return nil, fmt.Errorf("unsupported object %T", s.Live)
+	}
+
+	desired, err := clone(s.Desired)
+	if err != nil {
+		return nil, err
+	}
+
+	for ns, refs := range references(desired) {
+		for _, r := range refs {
+			if r.ID != "" {
+				continue
+			}
+
+			v, ok := resolver[ns+"/"+r.Name]
+			if !ok {
+				return nil, fmt.Errorf("unresolved reference %s: %w", r.Name, ErrNotFound)
+			}
+			*r = *v
+		}
+	}
+
+	if s.Action == PlanActionUpdate {
+		l := s.Live.(referencer).Reference()
+
+		switch o := desired.(type) {
+		case *NetworkObject:
+			o.ReferenceObject = *l
+			return o, f.UpdateNetworkObjectContext(ctx, o)
+		case *NetworkObjectGroup:
+			o.ReferenceObject = *l
+			return o, f.UpdateNetworkObjectGroupContext(ctx, o)
+		case *PortObject:
+			o.ReferenceObject = *l
+			return o, f.UpdatePortObjectContext(ctx, o)
+		case *PortObjectGroup:
+			o.ReferenceObject = *l
+			return o, f.UpdatePortObjectGroupContext(ctx, o)
+		case *AccessRule:
+			o.ReferenceObject = *l
+			o.parent = policy
+			return o, f.UpdateAccessRuleContext(ctx, o)
+		}
+		return nil, fmt.Errorf("unsupported object %T", s.Desired)
+	}
+
+	switch o := desired.(type) {
+	case *NetworkObject:
+		return o, f.CreateNetworkObjectContext(ctx, o, DuplicateActionError)
+	case *NetworkObjectGroup:
+		return o, f.CreateNetworkObjectGroupContext(ctx, o, DuplicateActionError)
+	case *PortObject:
+		return o, f.createPortObject(ctx, o, DuplicateActionError)
+	case *PortObjectGroup:
+		return o, f.CreatePortObjectGroupContext(ctx, o, DuplicateActionError)
+	case *AccessRule:
+		return o, f.CreateAccessRuleContext(ctx, o, policy)
+	}
+
+	return nil, fmt.Errorf("unsupported object %T", s.Desired)
+}
+
+// clone Returns a deep copy of a desired object, applying a plan leaves the desired state untouched
+func clone(obj interface{}) (interface{}, error) {
+	var retval interface{}
+
+	switch obj.(type) {
+	case *NetworkObject:
+		retval = new(NetworkObject)
+	case *NetworkObjectGroup:
+		retval = new(NetworkObjectGroup)
+	case *PortObject:
+		retval = new(PortObject)
+	case *PortObjectGroup:
+		retval = new(PortObjectGroup)
+	case *AccessRule:
+		retval = new(AccessRule)
+	default:
+		return nil, fmt.Errorf("unsupported object %T", obj)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, retval)
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
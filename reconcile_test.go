@@ -0,0 +1,282 @@
+package goftd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlanReconcile(t *testing.T) {
+	web := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.1"}
+	web.Name = "web01"
+	db := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.2"}
+	db.Name = "db01"
+
+	servers := &NetworkObjectGroup{Objects: []*ReferenceObject{{Name: "db01"}, {Name: "web01"}}}
+	servers.Name = "servers"
+
+	https := &PortObject{Port: "443"}
+	https.Name = "https"
+	https.Type = TypeTCPPortObject
+
+	rule := &AccessRule{RuleAction: RuleActionPermit, DestinationNetworks: []*ReferenceObject{{Name: "servers"}}, DestinationPorts: []*ReferenceObject{{Name: "https"}}}
+	rule.Name = "allow-servers"
+
+	desired := &DesiredState{
+		NetworkObjects:      []*NetworkObject{web, db},
+		NetworkObjectGroups: []*NetworkObjectGroup{servers},
+		PortObjects:         []*PortObject{https},
+		AccessRules:         []*AccessRule{rule},
+	}
+
+	liveWeb := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.1"}
+	liveWeb.ReferenceObject = ReferenceObject{ID: "1", Version: "a", Name: "web01", Type: "networkobject"}
+	liveServers := &NetworkObjectGroup{Objects: []*ReferenceObject{{ID: "1", Name: "web01", Type: "networkobject"}}}
+	liveServers.ReferenceObject = ReferenceObject{ID: "2", Version: "b", Name: "servers", Type: "networkobjectgroup"}
+	liveAny := &NetworkObject{SubType: NetworkObjectSubTypeNetwork, Value: "0.0.0.0/0", IsSystemDefined: true}
+	liveAny.ReferenceObject = ReferenceObject{ID: "3", Name: "any-ipv4", Type: "networkobject"}
+	liveRule := &AccessRule{RuleAction: RuleActionPermit}
+	liveRule.ReferenceObject = ReferenceObject{ID: "4", Name: "old-rule", Type: "accessrule"}
+
+	live := &DesiredState{
+		NetworkObjects:      []*NetworkObject{liveWeb, liveAny},
+		NetworkObjectGroups: []*NetworkObjectGroup{liveServers},
+		AccessRules:         []*AccessRule{liveRule},
+	}
+
+	plan, err := planReconcile(desired, live, false)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	expected := "CREATE networkobject db01\nUPDATE networkobjectgroup servers\nCREATE tcpportobject https\nCREATE accessrule allow-servers"
+	if plan.String() != expected {
+		t.Errorf("expecting plan:\n%s\ngot:\n%s\n", expected, plan)
+	}
+
+	plan, err = planReconcile(desired, live, true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	last := plan.Steps[len(plan.Steps)-1]
+	if len(plan.Steps) != 5 || last.String() != "DELETE accessrule old-rule" {
+		t.Errorf("expecting the old rule to be deleted last, got:\n%s\n", plan)
+	}
+
+	plan, err = planReconcile(live, live, true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if !plan.Empty() {
+		t.Errorf("expecting an empty plan, got:\n%s\n", plan)
+	}
+}
+
+func TestPlanReconcileDefaults(t *testing.T) {
+	rule := &AccessRule{RuleAction: RuleActionPermit, DestinationNetworks: []*ReferenceObject{{Name: "any-ipv4"}}}
+	rule.Name = "allow-any"
+
+	// Filled by the device with the defaults of the fields the rule leaves empty
+	liveRule := &AccessRule{RuleAction: RuleActionPermit, EventLogAction: LogActionNone, LogFiles: true,
+		DestinationNetworks: []*ReferenceObject{{ID: "3", Name: "any-ipv4", Version: "c", Type: "networkobject"}}}
+	liveRule.ReferenceObject = ReferenceObject{ID: "4", Version: "d", Name: "allow-any", Type: "accessrule"}
+	liveRule.RuleID = 268435457
+
+	liveAny := &NetworkObject{SubType: NetworkObjectSubTypeNetwork, Value: "0.0.0.0/0", IsSystemDefined: true}
+	liveAny.ReferenceObject = ReferenceObject{ID: "3", Name: "any-ipv4", Type: "networkobject"}
+
+	live := &DesiredState{
+		NetworkObjects: []*NetworkObject{liveAny},
+		AccessRules:    []*AccessRule{liveRule},
+	}
+
+	plan, err := planReconcile(&DesiredState{AccessRules: []*AccessRule{rule}}, live, false)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if !plan.Empty() {
+		t.Errorf("expecting the defaults of the device to be ignored, got:\n%s\n", plan)
+	}
+
+	rule.EventLogAction = LogActionFlowStart
+	plan, err = planReconcile(&DesiredState{AccessRules: []*AccessRule{rule}}, live, false)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if plan.String() != "UPDATE accessrule allow-any" {
+		t.Errorf("expecting the rule to be updated, got:\n%s\n", plan)
+	}
+}
+
+func TestPlanReconcilePruneNested(t *testing.T) {
+	o := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.1"}
+	o.ReferenceObject = ReferenceObject{ID: "1", Name: "o", Type: "networkobject"}
+	inner := &NetworkObjectGroup{Objects: []*ReferenceObject{{ID: "1", Name: "o", Type: "networkobject"}}}
+	inner.ReferenceObject = ReferenceObject{ID: "2", Name: "inner", Type: "networkobjectgroup"}
+	outer := &NetworkObjectGroup{Objects: []*ReferenceObject{{ID: "2", Name: "inner", Type: "networkobjectgroup"}}}
+	outer.ReferenceObject = ReferenceObject{ID: "3", Name: "outer", Type: "networkobjectgroup"}
+
+	live := &DesiredState{
+		NetworkObjects:      []*NetworkObject{o},
+		NetworkObjectGroups: []*NetworkObjectGroup{outer, inner},
+	}
+
+	plan, err := planReconcile(&DesiredState{}, live, true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	expected := "DELETE networkobjectgroup outer\nDELETE networkobjectgroup inner\nDELETE networkobject o"
+	if plan.String() != expected {
+		t.Errorf("expecting plan:\n%s\ngot:\n%s\n", expected, plan)
+	}
+}
+
+func TestPlanReconcilePruneReferenced(t *testing.T) {
+	o := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.1"}
+	o.ReferenceObject = ReferenceObject{ID: "1", Name: "o", Type: "networkobject"}
+	inner := &NetworkObjectGroup{Objects: []*ReferenceObject{{ID: "1", Name: "o", Type: "networkobject"}}}
+	inner.ReferenceObject = ReferenceObject{ID: "2", Name: "inner", Type: "networkobjectgroup"}
+	outer := &NetworkObjectGroup{Objects: []*ReferenceObject{{ID: "2", Name: "inner", Type: "networkobjectgroup"}}}
+	outer.ReferenceObject = ReferenceObject{ID: "3", Name: "outer", Type: "networkobjectgroup"}
+	db := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.2"}
+	db.ReferenceObject = ReferenceObject{ID: "4", Name: "db", Type: "networkobject"}
+	spare := &NetworkObject{SubType: NetworkObjectSubTypeHost, Value: "10.0.0.3"}
+	spare.ReferenceObject = ReferenceObject{ID: "5", Name: "spare", Type: "networkobject"}
+
+	live := &DesiredState{
+		NetworkObjects:      []*NetworkObject{o, db, spare},
+		NetworkObjectGroups: []*NetworkObjectGroup{outer, inner},
+	}
+
+	// The rule references outer by name, the group db by ID, both are missing from the desired state
+	rule := &AccessRule{RuleAction: RuleActionPermit, DestinationNetworks: []*ReferenceObject{{Name: "outer"}}}
+	rule.Name = "rule"
+	servers := &NetworkObjectGroup{Objects: []*ReferenceObject{{ID: "4", Name: "db", Type: "networkobject"}}}
+	servers.Name = "servers"
+
+	desired := &DesiredState{
+		NetworkObjectGroups: []*NetworkObjectGroup{servers},
+		AccessRules:         []*AccessRule{rule},
+	}
+
+	plan, err := planReconcile(desired, live, true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	expected := "CREATE networkobjectgroup servers\nCREATE accessrule rule\nDELETE networkobject spare"
+	if plan.String() != expected {
+		t.Errorf("expecting plan:\n%s\ngot:\n%s\n", expected, plan)
+	}
+}
+
+func TestPlanReconcileErrors(t *testing.T) {
+	a := &NetworkObjectGroup{Objects: []*ReferenceObject{{Name: "b"}}}
+	a.Name = "a"
+	b := &NetworkObjectGroup{Objects: []*ReferenceObject{{Name: "a"}}}
+	b.Name = "b"
+
+	_, err := planReconcile(&DesiredState{NetworkObjectGroups: []*NetworkObjectGroup{a, b}}, &DesiredState{}, false)
+	if err == nil {
+		t.Errorf("expecting a cycle error\n")
+	}
+
+	c := &NetworkObjectGroup{Objects: []*ReferenceObject{{Name: "missing"}}}
+	c.Name = "c"
+
+	_, err = planReconcile(&DesiredState{NetworkObjectGroups: []*NetworkObjectGroup{c}}, &DesiredState{}, false)
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+
+	p := &PortObject{Port: "443"}
+	p.Name = "https"
+
+	_, err = planReconcile(&DesiredState{PortObjects: []*PortObject{p}}, &DesiredState{}, false)
+	if err == nil {
+		t.Errorf("expecting an error for a port object without type\n")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n, _ := NewHostObject("testReconcileObj001", "10.10.10.1")
+	g := &NetworkObjectGroup{Objects: []*ReferenceObject{{Name: n.Name}}}
+	g.Name = "testReconcileGroup001"
+	a := &AccessRule{RuleAction: RuleActionPermit, DestinationNetworks: []*ReferenceObject{{Name: g.Name}}}
+	a.Name = "testReconcileRule001"
+
+	desired := &DesiredState{
+		NetworkObjects:      []*NetworkObject{n},
+		NetworkObjectGroups: []*NetworkObjectGroup{g},
+		AccessRules:         []*AccessRule{a},
+	}
+
+	plan, err := ftd.Reconcile(context.Background(), desired, ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(plan.Steps) != 3 || plan.Steps[0].Applied {
+		t.Errorf("unexpected dry run plan:\n%s\n", plan)
+	}
+
+	plan, err = ftd.Reconcile(context.Background(), desired, ReconcileOptions{})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	for _, s := range plan.Steps {
+		if !s.Applied || s.Live == nil {
+			t.Errorf("step %s was not applied\n", s)
+			return
+		}
+	}
+	applied := plan.Steps
+
+	if n.ID != "" || g.ID != "" || g.Objects[0].ID != "" || a.ID != "" || a.DestinationNetworks[0].ID != "" {
+		t.Errorf("the desired state was changed: %+v %+v %+v\n", n, g, a)
+	}
+
+	// Desired as read again from the configuration, without the fields the device filled in
+	rule := &AccessRule{RuleAction: RuleActionPermit, DestinationNetworks: []*ReferenceObject{{Name: g.Name}}}
+	rule.Name = a.Name
+	again := &DesiredState{
+		NetworkObjects:      desired.NetworkObjects,
+		NetworkObjectGroups: desired.NetworkObjectGroups,
+		AccessRules:         []*AccessRule{rule},
+	}
+
+	plan, err = ftd.Reconcile(context.Background(), again, ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if !plan.Empty() {
+		t.Errorf("expecting an empty plan, got:\n%s\n", plan)
+	}
+
+	err = ftd.DeleteAccessRule(applied[2].Live.(*AccessRule))
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	err = ftd.DeleteNetworkObjectGroup(applied[1].Live.(*NetworkObjectGroup))
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	err = ftd.DeleteNetworkObject(applied[0].Live.(*NetworkObject))
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+}
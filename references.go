@@ -0,0 +1,375 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Referrer An entity referencing an object, see FindReferences
+type Referrer struct {
+	ReferenceObject
+	// Policy of an access or NAT rule, empty for the other entities
+	Policy string
+	// Fields of the entity holding the reference, e.g. destinationNetworks
+	Fields []string
+
+	entity referencer
+}
+
+// InUseError Returned by SafeDelete when the object is still referenced, errors.Is(err, ErrInUse) matches it
+type InUseError struct {
+	Object    *ReferenceObject
+	Referrers []*Referrer
+}
+
+func (ie *InUseError) Error() string {
+	var by []string
+	for _, r := range ie.Referrers {
+		by = append(by, fmt.Sprintf("%s %s", r.Type, r.Name))
+	}
+
+	return fmt.Sprintf("%s %s is referenced by %s", ie.Object.Type, ie.Object.Name, strings.Join(by, ", "))
+}
+
+// Is Matches ErrInUse
+func (ie *InUseError) Is(target error) bool {
+	return target == ErrInUse
+}
+
+// FindReferences Returns the network and port groups, access rules, NAT rules and static routes referencing ref
+func (f *FTD) FindReferences(ref *ReferenceObject) ([]*Referrer, error) {
+	return f.FindReferencesContext(context.Background(), ref)
+}
+
+// FindReferencesContext Same as FindReferences, ctx cancels the requests
+func (f *FTD) FindReferencesContext(ctx context.Context, ref *ReferenceObject) ([]*Referrer, error) {
+	var retval []*Referrer
+
+	check := func(entity referencer, policy string) error {
+		r := entity.Reference()
+		if r.ID == ref.ID {
+			return nil
+		}
+
+		fields, err := referencingFields(entity, ref.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(fields) > 0 {
+			retval = append(retval, &Referrer{
+				ReferenceObject: *r,
+				Policy:          policy,
+				Fields:          fields,
+				entity:          entity,
+			})
+		}
+		return nil
+	}
+
+	err := f.iterNetworkObjectGroups(ctx, nil, 0, func(g *NetworkObjectGroup) error {
+		return check(g, "")
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	err = f.iterPortObjectGroups(ctx, nil, 0, func(g *PortObjectGroup) error {
+		return check(g, "")
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	err = f.iterAccessPolicies(ctx, 0, func(p *AccessPolicy) error {
+		return f.iterAccessRules(ctx, p.ID, nil, 0, func(a *AccessRule) error {
+			return check(a, p.ID)
+		})
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	err = f.iterNATPolicies(ctx, apiObjectNATPoliciesEndpoint, 0, func(p *NATPolicy) error {
+		return f.iterObjectNATRules(ctx, p.ID, nil, 0, func(n *ObjectNATRule) error {
+			return check(n, p.ID)
+		})
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	err = f.iterNATPolicies(ctx, apiManualNATPoliciesEndpoint, 0, func(p *NATPolicy) error {
+		return f.iterManualNATRules(ctx, p.ID, nil, 0, func(n *ManualNATRule) error {
+			return check(n, p.ID)
+		})
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	err = f.iterStaticRouteEntries(ctx, nil, 0, func(s *StaticRouteEntry) error {
+		return check(s, "")
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// referencingFields Returns the top level fields of entity holding a reference to id, sorted
+func referencingFields(entity interface{}, id string) ([]string, error) {
+	var retval []string
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range m {
+		if containsReference(v, id) {
+			retval = append(retval, k)
+		}
+	}
+	sort.Strings(retval)
+
+	return retval, nil
+}
+
+// containsReference Returns true if v is or contains a reference object to id
+func containsReference(v interface{}, id string) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if t["id"] == id {
+			return true
+		}
+		for _, e := range t {
+			if containsReference(e, id) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, e := range t {
+			if containsReference(e, id) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SafeDelete Deletes a network, port or protocol object, or a group, unless it is referenced.
+// A referenced object is refused with an *InUseError, unless cascade is set: the reference is then removed
+// from the groups, a group left empty is deleted the same way, and from the access rules and static routes.
+// Rules and routes are never deleted: when removing the reference would leave one of their fields empty,
+// which matches any, or when it is their only value, e.g. the network of a NAT rule or the gateway of a route,
+// the deletion is refused with an error matching ErrInUse. The referrers of the object are all checked before
+// any of them is changed, those of a group left empty only once that group is deleted.
+func (f *FTD) SafeDelete(ref *ReferenceObject, cascade bool) error {
+	return f.SafeDeleteContext(context.Background(), ref, cascade)
+}
+
+// SafeDeleteContext Same as SafeDelete, ctx cancels the requests
+func (f *FTD) SafeDeleteContext(ctx context.Context, ref *ReferenceObject, cascade bool) error {
+	var err error
+
+	endpoint, err := deleteEndpoint(ref.Type)
+	if err != nil {
+		return err
+	}
+
+	for {
+		refs, err := f.FindReferencesContext(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		if len(refs) == 0 {
+			break
+		}
+
+		if !cascade {
+			err = &InUseError{
+				Object:    ref,
+				Referrers: refs,
+			}
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		for _, r := range refs {
+			err = stripReference(r, ref)
+			if err != nil {
+				if f.debug {
+					f.logger.Errorf("Error: %s\n", err)
+				}
+				return err
+			}
+		}
+
+		stale := false
+		for _, r := range refs {
+			stale, err = f.unreference(ctx, r, ref.ID)
+			if err != nil {
+				if f.debug {
+					f.logger.Errorf("Error: %s\n", err)
+				}
+				return err
+			}
+
+			// Deleting an empty group removed it from the other referrers, or deleted them: find them again
+			if stale {
+				break
+			}
+		}
+
+		if !stale {
+			break
+		}
+	}
+
+	err = f.DeleteContext(ctx, fmt.Sprintf("%s/%s", endpoint, ref.ID))
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// stripReference Removes the reference to ref from the fields of a rule or a route, see unreference to update it.
+// Groups are left untouched.
+func stripReference(r *Referrer, ref *ReferenceObject) error {
+	switch e := r.entity.(type) {
+	case *AccessRule:
+		return withoutReferences(r, ref, map[string]*[]*ReferenceObject{
+			"sourceNetworks":      &e.SourceNetworks,
+			"destinationNetworks": &e.DestinationNetworks,
+			"sourcePorts":         &e.SourcePorts,
+			"destinationPorts":    &e.DestinationPorts,
+		})
+	case *ObjectNATRule, *ManualNATRule:
+		// Every field of a NAT rule holds a single reference
+		return withoutReferences(r, ref, nil)
+	case *StaticRouteEntry:
+		return withoutReferences(r, ref, map[string]*[]*ReferenceObject{
+			"networks": &e.Networks,
+		})
+	}
+
+	return nil
+}
+
+// withoutReferences Removes the references to ref from fields, by JSON name, failing with ErrInUse
+// when r references ref in another field or when a field would be left empty
+func withoutReferences(r *Referrer, ref *ReferenceObject, fields map[string]*[]*ReferenceObject) error {
+	for _, name := range r.Fields {
+		refs, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("%s %s holds %s %s in %s, which can't be removed: %w", r.Type, r.Name, ref.Type, ref.Name, name, ErrInUse)
+		}
+
+		left := withoutReference(*refs, ref.ID)
+		if len(left) == 0 {
+			return fmt.Errorf("removing %s %s from %s %s leaves %s empty, which matches any: %w", ref.Type, ref.Name, r.Type, r.Name, name, ErrInUse)
+		}
+		*refs = left
+	}
+
+	return nil
+}
+
+// unreference Removes the reference to id from a group, or updates the rule or the route stripped by stripReference.
+// stale is true when a group left empty was deleted, which changes the other entities referencing it.
+func (f *FTD) unreference(ctx context.Context, r *Referrer, id string) (stale bool, err error) {
+	switch e := r.entity.(type) {
+	case *NetworkObjectGroup:
+		e.Objects = withoutReference(e.Objects, id)
+		if len(e.Objects) == 0 {
+			return true, f.SafeDeleteContext(ctx, e.Reference(), true)
+		}
+		return false, f.UpdateNetworkObjectGroupContext(ctx, e)
+	case *PortObjectGroup:
+		e.Objects = withoutReference(e.Objects, id)
+		if len(e.Objects) == 0 {
+			return true, f.SafeDeleteContext(ctx, e.Reference(), true)
+		}
+		return false, f.UpdatePortObjectGroupContext(ctx, e)
+	case *AccessRule:
+		return false, f.UpdateAccessRuleContext(ctx, e)
+	case *StaticRouteEntry:
+		return false, f.UpdateStaticRouteEntryContext(ctx, e)
+	}
+
+	return false, fmt.Errorf("can't remove the reference from %s %s", r.Type, r.Name)
+}
+
+// withoutReference Returns refs without the references to id
+func withoutReference(refs []*ReferenceObject, id string) []*ReferenceObject {
+	var retval []*ReferenceObject
+
+	for _, r := range refs {
+		if r.ID != id {
+			retval = append(retval, r)
+		}
+	}
+
+	return retval
+}
+
+// deleteEndpoint Returns the endpoint of the objects of type objectType that SafeDelete supports
+func deleteEndpoint(objectType string) (string, error) {
+	switch objectType {
+	case TypeNetworkObject:
+		return apiNetworksEndpoint, nil
+	case TypeNetworkObjectGroup:
+		return apiNetworkGroupsEndpoint, nil
+	case TypeTCPPortObject:
+		return apiTCPPortObjectsEndpoint, nil
+	case TypeUDPPortObject:
+		return apiUDPPortObjectsEndpoint, nil
+	case TypeICMPv4PortObject:
+		return apiICMPv4PortObjectsEndpoint, nil
+	case TypeICMPv6PortObject:
+		return apiICMPv6PortObjectsEndpoint, nil
+	case TypeProtocolObject:
+		return apiProtocolObjectsEndpoint, nil
+	case "portobjectgroup":
+		return apiPortObjectGroupsEndpoint, nil
+	}
+
+	return "", fmt.Errorf("can't delete objects of type %s", objectType)
+}
@@ -0,0 +1,304 @@
+package goftd
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSafeDelete(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n, _ := NewHostObject("testObj001", "192.0.2.1")
+	n2, _ := NewHostObject("testObj002", "192.0.2.2")
+	for _, o := range []*NetworkObject{n, n2} {
+		err = ftd.CreateNetworkObject(o, DuplicateActionReplace)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+	}
+	defer ftd.DeleteNetworkObject(n2)
+
+	g := new(NetworkObjectGroup)
+	g.Name = "testObjGroup001"
+	g.Objects = append(g.Objects, n.Reference(), n2.Reference())
+
+	err = ftd.CreateNetworkObjectGroup(g, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObjectGroup(g)
+
+	a := new(AccessRule)
+	a.Name = "testPolicy001"
+	a.RuleAction = RuleActionPermit
+	a.EventLogAction = LogActionNone
+	a.DestinationNetworks = append(a.DestinationNetworks, n.Reference(), n2.Reference())
+
+	err = ftd.CreateAccessRule(a, "default")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteAccessRule(a)
+
+	// b only has n as destination, removing it would make the rule match any destination
+	b := new(AccessRule)
+	b.Name = "testPolicy002"
+	b.RuleAction = RuleActionPermit
+	b.EventLogAction = LogActionNone
+	b.DestinationNetworks = append(b.DestinationNetworks, n.Reference())
+
+	err = ftd.CreateAccessRule(b, "default")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	refs, err := ftd.FindReferences(n.Reference())
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(refs) != 3 {
+		t.Errorf("expecting 3 referrers, got %d\n", len(refs))
+		return
+	}
+
+	if refs[0].ID != g.ID || refs[0].Fields[0] != "objects" {
+		t.Errorf("expecting %s in objects, got %s in %v\n", g.Name, refs[0].Name, refs[0].Fields)
+	}
+
+	if refs[1].ID != a.ID || refs[1].Policy != "default" || refs[1].Fields[0] != "destinationNetworks" {
+		t.Errorf("expecting %s in destinationNetworks, got %s in %v\n", a.Name, refs[1].Name, refs[1].Fields)
+	}
+
+	err = ftd.SafeDelete(n.Reference(), false)
+
+	var ie *InUseError
+	if !errors.As(err, &ie) || !errors.Is(err, ErrInUse) || len(ie.Referrers) != 3 {
+		t.Errorf("expecting an in use error, got %v\n", err)
+	}
+
+	err = ftd.SafeDelete(n.Reference(), true)
+	if !errors.Is(err, ErrInUse) {
+		t.Errorf("expecting %s to be refused, got %v\n", b.Name, err)
+	}
+
+	refs, err = ftd.FindReferences(n.Reference())
+	if err != nil || len(refs) != 3 || len(refs[0].entity.(*NetworkObjectGroup).Objects) != 2 {
+		t.Errorf("expecting the referrers of %s to be left alone, got %v\n", n.Name, err)
+	}
+
+	err = ftd.DeleteAccessRule(b)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	err = ftd.SafeDelete(n.Reference(), true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ftd.GetNetworkObjectByID(n.ID)
+	if !IsNotFound(err) {
+		t.Errorf("expecting %s to be deleted, got %v\n", n.Name, err)
+	}
+
+	r, err := ftd.GetAccessRuleByID("default", a.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if len(r.DestinationNetworks) != 1 || r.DestinationNetworks[0].ID != n2.ID {
+		t.Errorf("expecting only %s left in %s, got %d networks\n", n2.Name, a.Name, len(r.DestinationNetworks))
+	}
+
+	refs, err = ftd.FindReferences(n2.Reference())
+	if err != nil || len(refs) != 2 || len(refs[0].entity.(*NetworkObjectGroup).Objects) != 1 {
+		t.Errorf("expecting %s to be left alone in %s and %s, got %v\n", n2.Name, g.Name, a.Name, err)
+	}
+}
+
+func TestSafeDeleteCascade(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n, _ := NewHostObject("testCascadeObj001", "192.0.2.11")
+	n2, _ := NewHostObject("testCascadeObj002", "192.0.2.12")
+	for _, o := range []*NetworkObject{n, n2} {
+		err = ftd.CreateNetworkObject(o, DuplicateActionReplace)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+	}
+	defer ftd.DeleteNetworkObject(n2)
+
+	// inner only holds n, deleting it once emptied changes outer and the rule
+	inner := new(NetworkObjectGroup)
+	inner.Name = "testCascadeGroup001"
+	inner.Objects = append(inner.Objects, n.Reference())
+
+	err = ftd.CreateNetworkObjectGroup(inner, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	outer := new(NetworkObjectGroup)
+	outer.Name = "testCascadeGroup002"
+	outer.Objects = append(outer.Objects, inner.Reference(), n.Reference(), n2.Reference())
+
+	err = ftd.CreateNetworkObjectGroup(outer, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObjectGroup(outer)
+
+	a := new(AccessRule)
+	a.Name = "testCascadeRule001"
+	a.RuleAction = RuleActionPermit
+	a.DestinationNetworks = append(a.DestinationNetworks, n.Reference(), inner.Reference(), n2.Reference())
+
+	err = ftd.CreateAccessRule(a, "default")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteAccessRule(a)
+
+	err = ftd.SafeDelete(n.Reference(), true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ftd.GetNetworkObjectByID(n.ID)
+	if !IsNotFound(err) {
+		t.Errorf("expecting %s to be deleted, got %v\n", n.Name, err)
+	}
+
+	err = ftd.getByID(context.Background(), apiNetworkGroupsEndpoint, inner.ID, new(NetworkObjectGroup))
+	if !IsNotFound(err) {
+		t.Errorf("expecting %s to be deleted, got %v\n", inner.Name, err)
+	}
+
+	r, err := ftd.GetAccessRuleByID("default", a.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if len(r.DestinationNetworks) != 1 || r.DestinationNetworks[0].ID != n2.ID {
+		t.Errorf("expecting only %s left in %s, got %d networks\n", n2.Name, a.Name, len(r.DestinationNetworks))
+	}
+
+	g := new(NetworkObjectGroup)
+	err = ftd.getByID(context.Background(), apiNetworkGroupsEndpoint, outer.ID, g)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if len(g.Objects) != 1 || g.Objects[0].ID != n2.ID {
+		t.Errorf("expecting only %s left in %s, got %d objects\n", n2.Name, outer.Name, len(g.Objects))
+	}
+}
+
+func TestSafeDeleteRouteAndNAT(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	n, _ := NewNetworkObject("testRefRouteNet001", "198.51.100.64/26")
+	n2, _ := NewNetworkObject("testRefRouteNet002", "198.51.100.128/26")
+	gw, _ := NewHostObject("testRefRouteGw001", "192.0.2.21")
+	src, _ := NewHostObject("testRefNatSrc001", "192.0.2.22")
+	for _, o := range []*NetworkObject{n, n2, gw, src} {
+		err = ftd.CreateNetworkObject(o, DuplicateActionReplace)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+			return
+		}
+		defer ftd.DeleteNetworkObject(o)
+	}
+
+	s := new(StaticRouteEntry)
+	s.Name = "testRefRoute001"
+	s.Networks = []*ReferenceObject{n.Reference(), n2.Reference()}
+	s.Gateway = gw.Reference()
+	s.MetricValue = 1
+	s.IPType = RouteIPTypeIPv4
+
+	err = ftd.CreateStaticRouteEntry(s)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteStaticRouteEntry(s)
+
+	p, err := ftd.GetObjectNATPolicyByName(ObjectNATPolicy)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	a := new(ObjectNATRule)
+	a.Name = "testRefNat001"
+	a.NATType = NATTypeStatic
+	a.OriginalNetwork = src.Reference()
+	a.TranslatedNetwork = gw.Reference()
+	a.Enabled = true
+
+	err = ftd.CreateObjectNATRule(a, p.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteObjectNATRule(a)
+
+	err = ftd.SafeDelete(n.Reference(), true)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	r, err := ftd.GetStaticRouteEntryByID(s.ID)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if len(r.Networks) != 1 || r.Networks[0].ID != n2.ID {
+		t.Errorf("expecting only %s left in %s, got %d networks\n", n2.Name, s.Name, len(r.Networks))
+	}
+
+	// The last network of the route, its gateway and the network of a NAT rule can't be removed
+	for _, o := range []*NetworkObject{n2, gw, src} {
+		err = ftd.SafeDelete(o.Reference(), true)
+		if !errors.Is(err, ErrInUse) {
+			t.Errorf("expecting %s to be refused, got %v\n", o.Name, err)
+		}
+
+		_, err = ftd.GetNetworkObjectByID(o.ID)
+		if err != nil {
+			t.Errorf("expecting %s to be kept, got %v\n", o.Name, err)
+		}
+	}
+
+	refs, err := ftd.FindReferences(gw.Reference())
+	if err != nil || len(refs) != 2 {
+		t.Errorf("expecting %s to be left in %s and %s, got %v\n", gw.Name, s.Name, a.Name, err)
+	}
+}
@@ -0,0 +1,119 @@
+package goftd
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy Controls how requests failing with a transient error are retried.
+// GET, PUT and DELETE are retried on network errors, 429, 502, 503 and 504.
+type RetryPolicy struct {
+	// MaxAttempts total number of attempts, 1 disables retries
+	MaxAttempts int
+	// InitialBackoff wait before the first retry, doubled after every attempt
+	InitialBackoff time.Duration
+	// MaxBackoff upper bound of the wait between two attempts, including the one asked by Retry-After
+	MaxBackoff time.Duration
+	// RetryPOST also retry POST requests, which are not idempotent
+	RetryPOST bool
+	// OnRetry optional hook called before waiting for the next attempt
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent Describes a failed attempt about to be retried
+type RetryEvent struct {
+	Method   string
+	Endpoint string
+	// Attempt number of the attempt that failed, starting at 1
+	Attempt int
+	// StatusCode 0 when no response was received
+	StatusCode int
+	Err        error
+	// Wait time before the next attempt
+	Wait time.Duration
+}
+
+// DefaultRetryPolicy Returns the policy used unless WithRetryPolicy is set
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// retryable Returns true if the attempt that failed should be retried
+func (p *RetryPolicy) retryable(method string, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if method == apiPOST && !p.RetryPOST {
+		return false
+	}
+
+	if resp == nil {
+		// Only retry when the device could not be reached
+		_, ok := err.(*url.Error)
+		return ok
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff Returns the wait before the next attempt, Retry-After takes precedence when set, capped at MaxBackoff
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+			return d
+		}
+	}
+
+	d := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	// Equal jitter, wait between d/2 and d
+	if d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+
+	return d
+}
+
+// retryAfter Parses a Retry-After header, in seconds or as an HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
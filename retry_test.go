@@ -0,0 +1,110 @@
+package goftd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	h := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"items":[]}`))
+	}
+
+	var events []RetryEvent
+	p := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		OnRetry: func(e RetryEvent) {
+			events = append(events, e)
+		},
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+	ftd.retryPolicy = p
+
+	_, err := ftd.Get(apiNetworksEndpoint, nil)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if calls != 3 {
+		t.Errorf("expecting 3 calls, got %d\n", calls)
+	}
+
+	if len(events) != 2 || events[0].StatusCode != http.StatusServiceUnavailable || events[1].Attempt != 2 {
+		t.Errorf("unexpected retry events: %+v\n", events)
+	}
+}
+
+func TestRetryPOST(t *testing.T) {
+	calls := 0
+	h := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+
+	p := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}
+
+	ftd, done := newStubFTD(t, h)
+	defer done()
+	ftd.retryPolicy = p
+
+	_, err := ftd.Post(apiNetworksEndpoint, nil)
+	if err == nil {
+		t.Errorf("expecting an error\n")
+	}
+
+	if calls != 1 {
+		t.Errorf("POST should not be retried, got %d calls\n", calls)
+	}
+
+	calls = 0
+	ftd.retryPolicy.RetryPOST = true
+
+	_, err = ftd.Post(apiNetworksEndpoint, nil)
+	if err == nil {
+		t.Errorf("expecting an error\n")
+	}
+
+	if calls != 3 {
+		t.Errorf("expecting 3 calls, got %d\n", calls)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     4 * time.Second,
+	}
+
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		d := p.backoff(attempt+1, nil)
+		if d < max/2 || d > max {
+			t.Errorf("attempt %d: expecting a wait between %s and %s, got %s\n", attempt+1, max/2, max, d)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	if d := p.backoff(1, resp); d != 3*time.Second {
+		t.Errorf("expecting Retry-After to be respected, got %s\n", d)
+	}
+
+	resp.Header.Set("Retry-After", "3600")
+	if d := p.backoff(1, resp); d != p.MaxBackoff {
+		t.Errorf("expecting Retry-After to be capped at %s, got %s\n", p.MaxBackoff, d)
+	}
+}
@@ -0,0 +1,317 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SecurityZone A group of interfaces used to match traffic in rules
+type SecurityZone struct {
+	ReferenceObject
+	Description string             `json:"description,omitempty"`
+	Mode        string             `json:"mode,omitempty"`
+	Interfaces  []*ReferenceObject `json:"interfaces,omitempty"`
+	Links       *Links             `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (z *SecurityZone) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      z.ID,
+		Name:    z.Name,
+		Version: z.Version,
+		Type:    z.Type,
+	}
+
+	return &r
+}
+
+// GetSecurityZones Get a list of security zones, a limit of 0 returns all of them
+func (f *FTD) GetSecurityZones(limit int) ([]*SecurityZone, error) {
+	return f.GetSecurityZonesContext(context.Background(), limit)
+}
+
+// GetSecurityZonesContext Same as GetSecurityZones, ctx cancels the requests
+func (f *FTD) GetSecurityZonesContext(ctx context.Context, limit int) ([]*SecurityZone, error) {
+	var err error
+	var retval []*SecurityZone
+
+	err = f.iterSecurityZones(ctx, nil, limit, func(z *SecurityZone) error {
+		retval = append(retval, z)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterSecurityZones Calls fn for every security zone, fetching one page at a time
+func (f *FTD) IterSecurityZones(ctx context.Context, fn func(*SecurityZone) error) error {
+	return f.iterSecurityZones(ctx, nil, 0, fn)
+}
+
+func (f *FTD) iterSecurityZones(ctx context.Context, query map[string]string, limit int, fn func(*SecurityZone) error) error {
+	return f.iterate(ctx, apiSecurityZonesEndpoint, query, limit, func(item json.RawMessage) error {
+		var z *SecurityZone
+
+		err := json.Unmarshal(item, &z)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(z)
+	})
+}
+
+func (f *FTD) getSecurityZoneBy(ctx context.Context, filterString string) ([]*SecurityZone, error) {
+	var err error
+	var retval []*SecurityZone
+
+	filter := make(map[string]string)
+	filter["filter"] = filterString
+
+	err = f.iterSecurityZones(ctx, filter, 0, func(z *SecurityZone) error {
+		retval = append(retval, z)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// GetSecurityZoneByID Get a security zone by ID
+func (f *FTD) GetSecurityZoneByID(id string) (*SecurityZone, error) {
+	return f.GetSecurityZoneByIDContext(context.Background(), id)
+}
+
+// GetSecurityZoneByIDContext Same as GetSecurityZoneByID, ctx cancels the requests
+func (f *FTD) GetSecurityZoneByIDContext(ctx context.Context, id string) (*SecurityZone, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiSecurityZonesEndpoint, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *SecurityZone
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetSecurityZoneByName Get a security zone by name
+func (f *FTD) GetSecurityZoneByName(name string) (*SecurityZone, error) {
+	return f.GetSecurityZoneByNameContext(context.Background(), name)
+}
+
+// GetSecurityZoneByNameContext Same as GetSecurityZoneByName, ctx cancels the requests
+func (f *FTD) GetSecurityZoneByNameContext(ctx context.Context, name string) (*SecurityZone, error) {
+	var err error
+
+	obj, err := f.getSecurityZoneBy(ctx, fmt.Sprintf("name:%s", name))
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	// The filter matches partial names
+	for i := range obj {
+		if obj[i].Name == name {
+			return obj[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("security zone %s: %w", name, ErrNotFound)
+}
+
+// CreateSecurityZone Create a new security zone, ROUTED when Mode is empty.
+// A zone replaced with DuplicateActionReplace keeps its mode when Mode is empty.
+func (f *FTD) CreateSecurityZone(z *SecurityZone, duplicateAction int) error {
+	return f.CreateSecurityZoneContext(context.Background(), z, duplicateAction)
+}
+
+// CreateSecurityZoneContext Same as CreateSecurityZone, ctx cancels the requests
+func (f *FTD) CreateSecurityZoneContext(ctx context.Context, z *SecurityZone, duplicateAction int) error {
+	var err error
+
+	z.Type = "securityzone"
+
+	// A new zone is ROUTED by default, a replaced one keeps its mode
+	n := *z
+	if n.Mode == "" {
+		n.Mode = SecurityZoneModeRouted
+	}
+
+	data, err := f.PostContext(ctx, apiSecurityZonesEndpoint, &n)
+	if err == nil {
+		err = json.Unmarshal(data, &z)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	if !IsDuplicate(err) || duplicateAction == DuplicateActionError {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	if f.debug {
+		f.logger.Warningf("This is a duplicate\n")
+	}
+
+	o, err := f.GetSecurityZoneByNameContext(ctx, z.Name)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	switch duplicateAction {
+	case DuplicateActionReplace:
+		o.Description = z.Description
+		if z.Mode != "" {
+			o.Mode = z.Mode
+		}
+		o.Interfaces = z.Interfaces
+
+		err = f.UpdateSecurityZoneContext(ctx, o)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+	}
+
+	*z = *o
+	return nil
+}
+
+// UpdateSecurityZone Updates a security zone
+func (f *FTD) UpdateSecurityZone(z *SecurityZone) error {
+	return f.UpdateSecurityZoneContext(context.Background(), z)
+}
+
+// UpdateSecurityZoneContext Same as UpdateSecurityZone, ctx cancels the requests
+func (f *FTD) UpdateSecurityZoneContext(ctx context.Context, z *SecurityZone) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiSecurityZonesEndpoint, z.ID)
+	data, err := f.PutContext(ctx, endpoint, z)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &z)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSecurityZone Delete a security zone
+func (f *FTD) DeleteSecurityZone(z *SecurityZone) error {
+	return f.DeleteSecurityZoneContext(context.Background(), z)
+}
+
+// DeleteSecurityZoneContext Same as DeleteSecurityZone, ctx cancels the requests
+func (f *FTD) DeleteSecurityZoneContext(ctx context.Context, z *SecurityZone) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiSecurityZonesEndpoint, z.ID)
+	err = f.DeleteContext(ctx, endpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// AddToSecurityZone Add an interface to a security zone
+func (f *FTD) AddToSecurityZone(z *SecurityZone, iface *ReferenceObject) error {
+	return f.AddToSecurityZoneContext(context.Background(), z, iface)
+}
+
+// AddToSecurityZoneContext Same as AddToSecurityZone, ctx cancels the requests
+func (f *FTD) AddToSecurityZoneContext(ctx context.Context, z *SecurityZone, iface *ReferenceObject) error {
+	var err error
+	for k := range z.Interfaces {
+		if z.Interfaces[k].ID == iface.ID {
+			return fmt.Errorf("interface already in security zone")
+		}
+	}
+
+	z.Interfaces = append(z.Interfaces, iface)
+
+	err = f.UpdateSecurityZoneContext(ctx, z)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteFromSecurityZone Remove an interface from a security zone
+func (f *FTD) DeleteFromSecurityZone(z *SecurityZone, iface *ReferenceObject) error {
+	return f.DeleteFromSecurityZoneContext(context.Background(), z, iface)
+}
+
+// DeleteFromSecurityZoneContext Same as DeleteFromSecurityZone, ctx cancels the requests
+func (f *FTD) DeleteFromSecurityZoneContext(ctx context.Context, z *SecurityZone, iface *ReferenceObject) error {
+	var err error
+	for k := range z.Interfaces {
+		if z.Interfaces[k].ID == iface.ID {
+			z.Interfaces = append(z.Interfaces[:k], z.Interfaces[k+1:]...)
+			break
+		}
+	}
+
+	err = f.UpdateSecurityZoneContext(ctx, z)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
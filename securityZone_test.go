@@ -0,0 +1,116 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestSecurityZone(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	z := new(SecurityZone)
+	z.Name = "testZone001"
+	z.Mode = SecurityZoneModeRouted
+
+	err = ftd.CreateSecurityZone(z, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if z.ID == "" || z.Version == "" {
+		t.Errorf("ID of value is not populated correctly\n")
+	}
+
+	z2, err := ftd.GetSecurityZoneByName(z.Name)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if z2.ID != z.ID {
+		t.Errorf("expected ID %s, got %s\n", z.ID, z2.ID)
+	}
+
+	a := new(AccessRule)
+	a.Name = "testZoneRule001"
+	a.RuleAction = RuleActionPermit
+	a.EventLogAction = LogActionNone
+	a.SourceZones = append(a.SourceZones, z.Reference())
+
+	err = ftd.CreateAccessRule(a, "default")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else {
+		err = ftd.DeleteAccessRule(a)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+	}
+
+	err = ftd.DeleteSecurityZone(z)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ftd.GetSecurityZoneByName(z.Name)
+	if !IsNotFound(err) {
+		t.Errorf("expecting not found, got %v\n", err)
+	}
+}
+
+func TestSecurityZoneDefaultMode(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	z := new(SecurityZone)
+	z.Name = "testZone002"
+
+	err = ftd.CreateSecurityZone(z, DuplicateActionError)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteSecurityZone(z)
+
+	if z.Mode != SecurityZoneModeRouted {
+		t.Errorf("expecting a new zone in mode %s, got %s\n", SecurityZoneModeRouted, z.Mode)
+	}
+
+	p := new(SecurityZone)
+	p.Name = "testZone003"
+	p.Mode = SecurityZoneModePassive
+
+	err = ftd.CreateSecurityZone(p, DuplicateActionError)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteSecurityZone(p)
+
+	// Replaced without mode, the zone stays passive
+	r := new(SecurityZone)
+	r.Name = p.Name
+	r.Description = "replaced"
+
+	err = ftd.CreateSecurityZone(r, DuplicateActionReplace)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if r.ID != p.ID || r.Mode != SecurityZoneModePassive || r.Description != "replaced" {
+		t.Errorf("expecting %s to stay %s with the new description, got %+v\n", p.Name, SecurityZoneModePassive, r)
+	}
+}
@@ -0,0 +1,126 @@
+package goftd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServiceObject A TCP, UDP, ICMP or protocol object, see ParseServiceObject
+type ServiceObject interface {
+	Reference() *ReferenceObject
+}
+
+// icmpv4Types FDM names of the common ICMPv4 types
+var icmpv4Types = map[int]string{
+	0:  "ECHO_REPLY",
+	3:  "DESTINATION_UNREACHABLE",
+	5:  "REDIRECT",
+	8:  "ECHO_REQUEST",
+	11: "TIME_EXCEEDED",
+	12: "PARAMETER_PROBLEM",
+}
+
+// icmpv6Types FDM names of the common ICMPv6 types
+var icmpv6Types = map[int]string{
+	1:   "DESTINATION_UNREACHABLE",
+	2:   "PACKET_TOO_BIG",
+	3:   "TIME_EXCEEDED",
+	4:   "PARAMETER_PROBLEM",
+	128: "ECHO_REQUEST",
+	129: "ECHO_REPLY",
+}
+
+// ParseServiceObject Returns an unsaved service object for protocol/value, the object is named after it.
+// tcp/443 and udp/5000-5100 return a *PortObject, icmp/8 and icmpv6/128 return an *ICMPPortObject
+// where the type is a number or an FDM name like icmp/ECHO_REQUEST, and ip/47 returns a *ProtocolObject.
+func ParseServiceObject(value string) (ServiceObject, error) {
+	value = strings.TrimSpace(value)
+	parts := strings.SplitN(value, "/", 2)
+	name := strings.Replace(value, "/", "_", 1)
+
+	protocol := strings.ToLower(parts[0])
+	arg := ""
+	if len(parts) == 2 {
+		arg = strings.TrimSpace(parts[1])
+	}
+
+	switch protocol {
+	case "tcp", "udp":
+		p := new(PortObject)
+		p.Name = name
+		p.Port = arg
+		p.Type = TypeTCPPortObject
+		if protocol == "udp" {
+			p.Type = TypeUDPPortObject
+		}
+
+		err := p.Validate()
+		if err != nil {
+			return nil, err
+		}
+
+		return p, nil
+	case "icmp", "icmpv4", "icmpv6":
+		p := new(ICMPPortObject)
+		p.Name = name
+
+		types := icmpv4Types
+		p.Type = TypeICMPv4PortObject
+		if protocol == "icmpv6" {
+			types = icmpv6Types
+			p.Type = TypeICMPv6PortObject
+		}
+
+		icmpType := strings.ToUpper(arg)
+		if n, err := strconv.Atoi(arg); err == nil {
+			t, ok := types[n]
+			if !ok {
+				return nil, fmt.Errorf("unknown %s type: %d", protocol, n)
+			}
+			icmpType = t
+		}
+
+		if p.Type == TypeICMPv4PortObject {
+			p.ICMPv4Type = icmpType
+		} else {
+			p.ICMPv6Type = icmpType
+		}
+
+		return p, nil
+	case "ip":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 || n > 255 {
+			return nil, fmt.Errorf("invalid protocol: %s", value)
+		}
+
+		p := new(ProtocolObject)
+		p.Name = name
+		p.Protocol = arg
+		p.Type = TypeProtocolObject
+
+		return p, nil
+	}
+
+	return nil, fmt.Errorf("unable to parse service object: %s", value)
+}
+
+// CreateServiceObject Creates a service object returned by ParseServiceObject
+func (f *FTD) CreateServiceObject(s ServiceObject, duplicateAction int) error {
+	return f.CreateServiceObjectContext(context.Background(), s, duplicateAction)
+}
+
+// CreateServiceObjectContext Same as CreateServiceObject, ctx cancels the requests
+func (f *FTD) CreateServiceObjectContext(ctx context.Context, s ServiceObject, duplicateAction int) error {
+	switch o := s.(type) {
+	case *PortObject:
+		return f.createPortObject(ctx, o, duplicateAction)
+	case *ICMPPortObject:
+		return f.createICMPPortObject(ctx, o, duplicateAction)
+	case *ProtocolObject:
+		return f.CreateProtocolObjectContext(ctx, o, duplicateAction)
+	}
+
+	return fmt.Errorf("unsupported service object: %T", s)
+}
@@ -0,0 +1,67 @@
+package goftd
+
+import (
+	"testing"
+)
+
+func TestPortObjectValidate(t *testing.T) {
+	for _, port := range []string{"443", "1000-2000", "1-65535", "80-80"} {
+		p := &PortObject{Port: port}
+		err := p.Validate()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s\n", port, err)
+		}
+	}
+
+	for _, port := range []string{"", "0", "65536", "2000-1000", "80-", "http"} {
+		p := &PortObject{Port: port}
+		err := p.Validate()
+		if err == nil {
+			t.Errorf("%q: expecting an error\n", port)
+		}
+	}
+}
+
+func TestParseServiceObject(t *testing.T) {
+	s, err := ParseServiceObject("udp/5000-5100")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if p, ok := s.(*PortObject); !ok || p.Type != TypeUDPPortObject || p.Port != "5000-5100" || p.Name != "udp_5000-5100" {
+		t.Errorf("unexpected object %+v\n", s)
+	}
+
+	s, err = ParseServiceObject("TCP/443")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if p, ok := s.(*PortObject); !ok || p.Type != TypeTCPPortObject || p.Port != "443" {
+		t.Errorf("unexpected object %+v\n", s)
+	}
+
+	s, err = ParseServiceObject("icmp/8")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if p, ok := s.(*ICMPPortObject); !ok || p.Type != TypeICMPv4PortObject || p.ICMPv4Type != "ECHO_REQUEST" {
+		t.Errorf("unexpected object %+v\n", s)
+	}
+
+	s, err = ParseServiceObject("icmpv6/128")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if p, ok := s.(*ICMPPortObject); !ok || p.Type != TypeICMPv6PortObject || p.ICMPv6Type != "ECHO_REQUEST" {
+		t.Errorf("unexpected object %+v\n", s)
+	}
+
+	s, err = ParseServiceObject("ip/47")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if p, ok := s.(*ProtocolObject); !ok || p.Protocol != "47" {
+		t.Errorf("unexpected object %+v\n", s)
+	}
+
+	for _, value := range []string{"tcp/70000", "udp/2000-1000", "icmp/250", "ip/300", "sctp/80", "443"} {
+		_, err = ParseServiceObject(value)
+		if err == nil {
+			t.Errorf("%s: expecting an error\n", value)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// StaticRouteEntry Static route of the default virtual router
+type StaticRouteEntry struct {
+	ReferenceObject
+	Description string             `json:"description,omitempty"`
+	Interface   *ReferenceObject   `json:"iface,omitempty"`
+	Networks    []*ReferenceObject `json:"networks,omitempty"`
+	Gateway     *ReferenceObject   `json:"gateway,omitempty"`
+	MetricValue int                `json:"metricValue,omitempty"`
+	IPType      string             `json:"ipType,omitempty"`
+	SLAMonitor  *ReferenceObject   `json:"slaMonitor,omitempty"`
+	Links       *Links             `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (s *StaticRouteEntry) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      s.ID,
+		Name:    s.Name,
+		Version: s.Version,
+		Type:    s.Type,
+	}
+
+	return &r
+}
+
+// GetStaticRouteEntries Get a list of static routes, a limit of 0 returns all of them
+func (f *FTD) GetStaticRouteEntries(limit int) ([]*StaticRouteEntry, error) {
+	return f.GetStaticRouteEntriesContext(context.Background(), limit)
+}
+
+// GetStaticRouteEntriesContext Same as GetStaticRouteEntries, ctx cancels the requests
+func (f *FTD) GetStaticRouteEntriesContext(ctx context.Context, limit int) ([]*StaticRouteEntry, error) {
+	var err error
+	var retval []*StaticRouteEntry
+
+	err = f.iterStaticRouteEntries(ctx, nil, limit, func(s *StaticRouteEntry) error {
+		retval = append(retval, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterStaticRouteEntries Calls fn for every static route, fetching one page at a time
+func (f *FTD) IterStaticRouteEntries(ctx context.Context, fn func(*StaticRouteEntry) error) error {
+	return f.iterStaticRouteEntries(ctx, nil, 0, fn)
+}
+
+func (f *FTD) iterStaticRouteEntries(ctx context.Context, query map[string]string, limit int, fn func(*StaticRouteEntry) error) error {
+	return f.iterate(ctx, apiStaticRouteEntriesEndpoint, query, limit, func(item json.RawMessage) error {
+		var s *StaticRouteEntry
+
+		err := json.Unmarshal(item, &s)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(s)
+	})
+}
+
+// GetStaticRouteEntryByID Get a static route by ID
+func (f *FTD) GetStaticRouteEntryByID(id string) (*StaticRouteEntry, error) {
+	return f.GetStaticRouteEntryByIDContext(context.Background(), id)
+}
+
+// GetStaticRouteEntryByIDContext Same as GetStaticRouteEntryByID, ctx cancels the requests
+func (f *FTD) GetStaticRouteEntryByIDContext(ctx context.Context, id string) (*StaticRouteEntry, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiStaticRouteEntriesEndpoint, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *StaticRouteEntry
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetStaticRouteEntryByName Get a static route by name
+func (f *FTD) GetStaticRouteEntryByName(name string) (*StaticRouteEntry, error) {
+	return f.GetStaticRouteEntryByNameContext(context.Background(), name)
+}
+
+// GetStaticRouteEntryByNameContext Same as GetStaticRouteEntryByName, ctx cancels the requests
+func (f *FTD) GetStaticRouteEntryByNameContext(ctx context.Context, name string) (*StaticRouteEntry, error) {
+	var retval *StaticRouteEntry
+
+	filter := make(map[string]string)
+	filter["filter"] = fmt.Sprintf("name:%s", name)
+
+	err := f.iterStaticRouteEntries(ctx, filter, 0, func(s *StaticRouteEntry) error {
+		if s.Name == name {
+			retval = s
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("static route %s: %w", name, ErrNotFound)
+	}
+
+	return retval, nil
+}
+
+// CreateStaticRouteEntry Create a new static route
+func (f *FTD) CreateStaticRouteEntry(s *StaticRouteEntry) error {
+	return f.CreateStaticRouteEntryContext(context.Background(), s)
+}
+
+// CreateStaticRouteEntryContext Same as CreateStaticRouteEntry, ctx cancels the requests
+func (f *FTD) CreateStaticRouteEntryContext(ctx context.Context, s *StaticRouteEntry) error {
+	var err error
+
+	s.Type = "staticrouteentry"
+
+	data, err := f.PostContext(ctx, apiStaticRouteEntriesEndpoint, s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CreateStaticRouteEntryFromCIDRs Create a static route to cidrs through gateway on iface.
+// The network objects for the networks and the gateway are reused when they exist, created otherwise.
+// cidrs must be of the address family of gateway, an existing object named after a CIDR or the gateway must have its value.
+// When the route can't be created, the objects created for it are deleted.
+func (f *FTD) CreateStaticRouteEntryFromCIDRs(name string, iface *ReferenceObject, gateway string, cidrs []string, metric int) (*StaticRouteEntry, error) {
+	return f.CreateStaticRouteEntryFromCIDRsContext(context.Background(), name, iface, gateway, cidrs, metric)
+}
+
+// CreateStaticRouteEntryFromCIDRsContext Same as CreateStaticRouteEntryFromCIDRs, ctx cancels the requests
+func (f *FTD) CreateStaticRouteEntryFromCIDRsContext(ctx context.Context, name string, iface *ReferenceObject, gateway string, cidrs []string, metric int) (*StaticRouteEntry, error) {
+	var err error
+
+	ip := net.ParseIP(gateway)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid gateway: %s", gateway)
+	}
+
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR: %s", cidr)
+		}
+
+		if (ipNet.IP.To4() == nil) != (ip.To4() == nil) {
+			return nil, fmt.Errorf("network %s and gateway %s are not of the same address family", cidr, gateway)
+		}
+	}
+
+	s := new(StaticRouteEntry)
+	s.Name = name
+	s.Interface = iface
+	s.MetricValue = metric
+	s.IPType = RouteIPTypeIPv4
+	if ip.To4() == nil {
+		s.IPType = RouteIPTypeIPv6
+	}
+
+	var objs []*NetworkObject
+	for _, cidr := range cidrs {
+		n, err := parseNetwork(cidr)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, n)
+	}
+
+	host, err := NewHostObject(ip.String(), ip.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// The gateway comes last, the objects created here are deleted if the route can't be created
+	networks, created, err := f.createNetworkObjects(ctx, append(objs, host))
+	if err != nil {
+		f.deleteNetworkObjects(ctx, created)
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+	gw := networks[len(cidrs)]
+
+	for i := range cidrs {
+		// An existing object with the same name is reused as is, whatever its value
+		if networks[i].SubType != NetworkObjectSubTypeNetwork || networks[i].Value != objs[i].Value {
+			err = fmt.Errorf("network object %s has value %s, expecting %s", networks[i].Name, networks[i].Value, objs[i].Value)
+			break
+		}
+
+		s.Networks = append(s.Networks, networks[i].Reference())
+	}
+
+	if err == nil && (gw.SubType != NetworkObjectSubTypeHost || !ip.Equal(net.ParseIP(gw.Value))) {
+		err = fmt.Errorf("network object %s has value %s, expecting %s", gw.Name, gw.Value, gateway)
+	}
+
+	if err == nil {
+		s.Gateway = gw.Reference()
+		err = f.CreateStaticRouteEntryContext(ctx, s)
+	}
+
+	if err != nil {
+		f.deleteNetworkObjects(ctx, created)
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// UpdateStaticRouteEntry Updates a static route
+func (f *FTD) UpdateStaticRouteEntry(s *StaticRouteEntry) error {
+	return f.UpdateStaticRouteEntryContext(context.Background(), s)
+}
+
+// UpdateStaticRouteEntryContext Same as UpdateStaticRouteEntry, ctx cancels the requests
+func (f *FTD) UpdateStaticRouteEntryContext(ctx context.Context, s *StaticRouteEntry) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiStaticRouteEntriesEndpoint, s.ID)
+	data, err := f.PutContext(ctx, endpoint, s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStaticRouteEntry Delete a static route, the network objects it references are kept
+func (f *FTD) DeleteStaticRouteEntry(s *StaticRouteEntry) error {
+	return f.DeleteStaticRouteEntryContext(context.Background(), s)
+}
+
+// DeleteStaticRouteEntryContext Same as DeleteStaticRouteEntry, ctx cancels the requests
+func (f *FTD) DeleteStaticRouteEntryContext(ctx context.Context, s *StaticRouteEntry) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiStaticRouteEntriesEndpoint, s.ID)
+	err = f.DeleteContext(ctx, endpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package goftd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStaticRouteEntry(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	i, err := ftd.GetPhysicalInterfaces(0)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	var iface *PhysicalInterface
+	for _, v := range i {
+		if v.Name != "" && v.IPv4 != nil && v.IPv4.IPType == IPTypeStatic {
+			iface = v
+			break
+		}
+	}
+
+	if iface == nil {
+		t.Skip("no named interface with a static address\n")
+	}
+
+	s, err := ftd.CreateStaticRouteEntryFromCIDRs("testRoute001", iface.Reference(), "192.0.2.254", []string{"198.51.100.0/24", "203.0.113.1/24"}, 1)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if len(s.Networks) != 2 {
+		t.Errorf("expecting 2 networks, got %d\n", len(s.Networks))
+	}
+
+	s2, err := ftd.GetStaticRouteEntryByName(s.Name)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	} else if s2.ID != s.ID {
+		t.Errorf("expected ID %s, got %s\n", s.ID, s2.ID)
+	}
+
+	err = ftd.DeleteStaticRouteEntry(s)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	for _, r := range append(s.Networks, s.Gateway) {
+		err = ftd.DeleteNetworkObjectByID(r.ID)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+	}
+}
+
+func TestStaticRouteEntryFromCIDRsMismatch(t *testing.T) {
+	var err error
+
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ftd.CreateStaticRouteEntryFromCIDRs("testRoute002", nil, "192.0.2.254", []string{"2001:db8::/32"}, 1)
+	if err == nil {
+		t.Errorf("expecting an error mixing an IPv4 gateway and an IPv6 network\n")
+	}
+
+	// Named like the object the helper would create, with another value
+	n, err := NewNetworkObject("198.18.0.0_24", "198.18.0.0/25")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	err = ftd.CreateNetworkObject(n, DuplicateActionError)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObject(n)
+
+	_, err = ftd.CreateStaticRouteEntryFromCIDRs("testRoute003", nil, "192.0.2.254", []string{"198.18.0.0/24"}, 1)
+	if err == nil {
+		t.Errorf("expecting an error reusing %s with value %s\n", n.Name, n.Value)
+	}
+	checkNetworkObjects(t, ftd, map[string]bool{n.Name: true, "192.0.2.254": false})
+
+	gw, err := NewHostObject("192.0.2.253", "192.0.2.252")
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	err = ftd.CreateNetworkObject(gw, DuplicateActionError)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+	defer ftd.DeleteNetworkObject(gw)
+
+	_, err = ftd.CreateStaticRouteEntryFromCIDRs("testRoute004", nil, "192.0.2.253", []string{"198.51.100.0/24"}, 1)
+	if err == nil {
+		t.Errorf("expecting an error reusing %s with value %s as gateway\n", gw.Name, gw.Value)
+	}
+	checkNetworkObjects(t, ftd, map[string]bool{gw.Name: true, "198.51.100.0_24": false})
+
+	s, err := ftd.CreateStaticRouteEntryFromCIDRs("testRoute005", nil, "192.0.2.254", []string{"198.18.1.0/24"}, 1)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	// The route can't be created with the same name, the new objects are deleted, the reused ones kept
+	_, err = ftd.CreateStaticRouteEntryFromCIDRs(s.Name, nil, "192.0.2.254", []string{"198.18.1.0/24", "198.18.2.0/24"}, 1)
+	if err == nil {
+		t.Errorf("expecting an error creating %s twice\n", s.Name)
+	}
+	checkNetworkObjects(t, ftd, map[string]bool{"198.18.1.0_24": true, "192.0.2.254": true, "198.18.2.0_24": false})
+
+	err = ftd.DeleteStaticRouteEntry(s)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	for _, r := range append(s.Networks, s.Gateway) {
+		err = ftd.DeleteNetworkObjectByID(r.ID)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+	}
+}
+
+// checkNetworkObjects Checks the network objects named in exists are there, or not
+func checkNetworkObjects(t *testing.T, ftd *FTD, exists map[string]bool) {
+	for name, want := range exists {
+		err := ftd.findByName(context.Background(), apiNetworksEndpoint, name, new(NetworkObject))
+		if want && err != nil {
+			t.Errorf("expecting %s to be kept, got %s\n", name, err)
+		} else if !want && !IsNotFound(err) {
+			t.Errorf("expecting %s to be deleted, got %v\n", name, err)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SubInterface A VLAN tagged sub-interface of a physical interface
+type SubInterface struct {
+	ReferenceObject
+	Description      string         `json:"description,omitempty"`
+	HardwareName     string         `json:"hardwareName,omitempty"`
+	SubInterfaceID   int            `json:"subIntfId"`
+	VLANID           int            `json:"vlanId"`
+	MonitorInterface bool           `json:"monitorInterface,omitempty"`
+	IPv4             *InterfaceIPv4 `json:"ipv4,omitempty"`
+	IPv6             *InterfaceIPv6 `json:"ipv6,omitempty"`
+	ManagementOnly   bool           `json:"managementOnly,omitempty"`
+	Mode             string         `json:"mode,omitempty"`
+	MTU              int            `json:"mtu,omitempty"`
+	Enabled          bool           `json:"enabled"`
+	Links            *Links         `json:"links,omitempty"`
+	parent           string
+}
+
+// Reference Returns a reference object
+func (s *SubInterface) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      s.ID,
+		Name:    s.Name,
+		Version: s.Version,
+		Type:    s.Type,
+	}
+
+	return &r
+}
+
+// GetSubInterfaces Get the sub-interfaces of a physical interface, a limit of 0 returns all of them
+func (f *FTD) GetSubInterfaces(parent string, limit int) ([]*SubInterface, error) {
+	return f.GetSubInterfacesContext(context.Background(), parent, limit)
+}
+
+// GetSubInterfacesContext Same as GetSubInterfaces, ctx cancels the requests
+func (f *FTD) GetSubInterfacesContext(ctx context.Context, parent string, limit int) ([]*SubInterface, error) {
+	var err error
+	var retval []*SubInterface
+
+	err = f.iterSubInterfaces(ctx, parent, limit, func(s *SubInterface) error {
+		retval = append(retval, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterSubInterfaces Calls fn for every sub-interface of a physical interface, fetching one page at a time
+func (f *FTD) IterSubInterfaces(ctx context.Context, parent string, fn func(*SubInterface) error) error {
+	return f.iterSubInterfaces(ctx, parent, 0, fn)
+}
+
+func (f *FTD) iterSubInterfaces(ctx context.Context, parent string, limit int, fn func(*SubInterface) error) error {
+	endpoint := fmt.Sprintf("%s/%s/subinterfaces", apiInterfacesEndpoint, parent)
+	return f.iterate(ctx, endpoint, nil, limit, func(item json.RawMessage) error {
+		var s *SubInterface
+
+		err := json.Unmarshal(item, &s)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		s.parent = parent
+		return fn(s)
+	})
+}
+
+// GetSubInterfaceByID Get a sub-interface of a physical interface by ID
+func (f *FTD) GetSubInterfaceByID(parent, id string) (*SubInterface, error) {
+	return f.GetSubInterfaceByIDContext(context.Background(), parent, id)
+}
+
+// GetSubInterfaceByIDContext Same as GetSubInterfaceByID, ctx cancels the requests
+func (f *FTD) GetSubInterfaceByIDContext(ctx context.Context, parent, id string) (*SubInterface, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s/subinterfaces/%s", apiInterfacesEndpoint, parent, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *SubInterface
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	v.parent = parent
+	return v, nil
+}
+
+// GetSubInterfaceByHardwareName Get a sub-interface by hardware name, e.g. GigabitEthernet0/1.100
+func (f *FTD) GetSubInterfaceByHardwareName(hardwareName string) (*SubInterface, error) {
+	return f.GetSubInterfaceByHardwareNameContext(context.Background(), hardwareName)
+}
+
+// GetSubInterfaceByHardwareNameContext Same as GetSubInterfaceByHardwareName, ctx cancels the requests
+func (f *FTD) GetSubInterfaceByHardwareNameContext(ctx context.Context, hardwareName string) (*SubInterface, error) {
+	var retval *SubInterface
+
+	i := strings.LastIndex(hardwareName, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid sub-interface hardware name: %s", hardwareName)
+	}
+
+	p, err := f.GetPhysicalInterfaceByHardwareNameContext(ctx, hardwareName[:i])
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.iterSubInterfaces(ctx, p.ID, 0, func(s *SubInterface) error {
+		if s.HardwareName == hardwareName {
+			retval = s
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("interface %s: %w", hardwareName, ErrNotFound)
+	}
+
+	return retval, nil
+}
+
+// CreateSubInterface Create a new sub-interface on a physical interface
+func (f *FTD) CreateSubInterface(parent string, s *SubInterface) error {
+	return f.CreateSubInterfaceContext(context.Background(), parent, s)
+}
+
+// CreateSubInterfaceContext Same as CreateSubInterface, ctx cancels the requests
+func (f *FTD) CreateSubInterfaceContext(ctx context.Context, parent string, s *SubInterface) error {
+	var err error
+
+	s.Type = "subinterface"
+
+	endpoint := fmt.Sprintf("%s/%s/subinterfaces", apiInterfacesEndpoint, parent)
+	data, err := f.PostContext(ctx, endpoint, s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	s.parent = parent
+
+	return nil
+}
+
+// UpdateSubInterface Updates a sub-interface, s must come from the device, e.g. from GetSubInterfaceByID
+func (f *FTD) UpdateSubInterface(s *SubInterface) error {
+	return f.UpdateSubInterfaceContext(context.Background(), s)
+}
+
+// UpdateSubInterfaceContext Same as UpdateSubInterface, ctx cancels the requests
+func (f *FTD) UpdateSubInterfaceContext(ctx context.Context, s *SubInterface) error {
+	var err error
+
+	if s.parent == "" {
+		err = errNoParent("sub-interface", s.ID, "parent interface", "GetSubInterfaceByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/subinterfaces/%s", apiInterfacesEndpoint, s.parent, s.ID)
+	data, err := f.PutContext(ctx, endpoint, s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSubInterface Delete a sub-interface, s must come from the device, e.g. from GetSubInterfaceByID
+func (f *FTD) DeleteSubInterface(s *SubInterface) error {
+	return f.DeleteSubInterfaceContext(context.Background(), s)
+}
+
+// DeleteSubInterfaceContext Same as DeleteSubInterface, ctx cancels the requests
+func (f *FTD) DeleteSubInterfaceContext(ctx context.Context, s *SubInterface) error {
+	var err error
+
+	if s.parent == "" {
+		err = errNoParent("sub-interface", s.ID, "parent interface", "GetSubInterfaceByID")
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/subinterfaces/%s", apiInterfacesEndpoint, s.parent, s.ID)
+	err = f.DeleteContext(ctx, endpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -1,25 +1,30 @@
 package goftd
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/golang/glog"
 )
 
 // GetNetworkAny Returns the 0.0.0.0/0 object
 func (f *FTD) GetNetworkAny() (*NetworkObject, error) {
-	obj, err := f.getNetworkObjectBy("name:0.0.0.0", 1)
+	return f.GetNetworkAnyContext(context.Background())
+}
+
+// GetNetworkAnyContext Same as GetNetworkAny, ctx cancels the requests
+func (f *FTD) GetNetworkAnyContext(ctx context.Context) (*NetworkObject, error) {
+	obj, err := f.getNetworkObjectBy(ctx, "name:0.0.0.0", 1)
 	if err != nil {
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
 		return nil, err
 	}
 	if len(obj) != 1 {
+		err = fmt.Errorf("network object 0.0.0.0: %w", ErrNotFound)
 		if f.debug {
-			glog.Errorf("Error: %s\n", err)
+			f.logger.Errorf("Error: %s\n", err)
 		}
-		return nil, fmt.Errorf("Error: %s", err)
+		return nil, err
 	}
 
 	return obj[0], nil
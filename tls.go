@@ -0,0 +1,55 @@
+package goftd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// newTLSConfig Returns the TLS settings used to talk to the device
+func (f *FTD) newTLSConfig() *tls.Config {
+	c := &tls.Config{
+		InsecureSkipVerify: f.Insecure,
+		RootCAs:            f.rootCAs,
+		Certificates:       f.clientCerts,
+	}
+
+	if f.fingerprint != nil {
+		// The pinned certificate is trusted on its own, no need for a chain
+		c.InsecureSkipVerify = true
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyFingerprint(cs, f.fingerprint)
+		}
+	}
+
+	return c
+}
+
+// parseFingerprint Parses a SHA-256 fingerprint, with or without colons
+func parseFingerprint(fingerprint string) ([]byte, error) {
+	s := strings.Replace(strings.TrimSpace(fingerprint), ":", "", -1)
+
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != sha256.Size {
+		return nil, fmt.Errorf("invalid SHA-256 fingerprint: %s", fingerprint)
+	}
+
+	return b, nil
+}
+
+// verifyFingerprint Checks the certificate presented by the device against the pinned fingerprint
+func verifyFingerprint(cs tls.ConnectionState, fingerprint []byte) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate presented by the device")
+	}
+
+	sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+	if !bytes.Equal(sum[:], fingerprint) {
+		return fmt.Errorf("certificate fingerprint mismatch, got %s", hex.EncodeToString(sum[:]))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package goftd
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseFingerprint(t *testing.T) {
+	sum := sha256.Sum256([]byte("ftd"))
+	plain := hex.EncodeToString(sum[:])
+
+	var parts []string
+	for i := 0; i < len(plain); i += 2 {
+		parts = append(parts, strings.ToUpper(plain[i:i+2]))
+	}
+
+	for _, s := range []string{plain, strings.Join(parts, ":")} {
+		_, err := parseFingerprint(s)
+		if err != nil {
+			t.Errorf("error: %s\n", err)
+		}
+	}
+
+	_, err := parseFingerprint("abcd")
+	if err == nil {
+		t.Errorf("expecting an error for a short fingerprint\n")
+	}
+}
+
+func TestTLSVerification(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	sum := sha256.Sum256(srv.Certificate().Raw)
+	wrong := sha256.Sum256([]byte("ftd"))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	tests := []struct {
+		name string
+		f    *FTD
+		ok   bool
+	}{
+		{"verify", &FTD{}, false},
+		{"insecure", &FTD{Insecure: true}, true},
+		{"pool", &FTD{rootCAs: pool}, true},
+		{"fingerprint", &FTD{fingerprint: sum[:]}, true},
+		{"wrong fingerprint", &FTD{fingerprint: wrong[:]}, false},
+	}
+
+	for _, tt := range tests {
+		resp, err := tt.f.newHTTPClient().Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		if tt.ok && err != nil {
+			t.Errorf("%s: error: %s\n", tt.name, err)
+		} else if !tt.ok && err == nil {
+			t.Errorf("%s: expecting an error\n", tt.name)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Token Tokens issued by the device
+type Token struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in,omitempty"`
+	TokenType        string `json:"token_type,omitempty"`
+}
+
+// customTokenRequest Returns the body of a custom_token grant
+func customTokenRequest(accessToken, subject string, expiresIn, refreshExpiresIn int) map[string]interface{} {
+	req := make(map[string]interface{})
+	req["grant_type"] = grantTypeCustom
+	req["access_token"] = accessToken
+
+	if subject != "" {
+		req["desired_subject"] = subject
+	}
+
+	if expiresIn > 0 {
+		req["desired_expires_in"] = expiresIn
+	}
+
+	if refreshExpiresIn > 0 {
+		req["desired_refresh_expires_in"] = refreshExpiresIn
+	}
+
+	return req
+}
+
+// updateToken Requests a new token using the configured grant
+func (f *FTD) updateToken(ctx context.Context) error {
+	if f.GrantType == "" {
+		return fmt.Errorf("grant is not correctly initialized")
+	}
+
+	if f.GrantType == grantTypePassword && f.passwordGrant == nil {
+		return fmt.Errorf("grant is not correctly initialized")
+	} else if f.GrantType == grantTypeCustom && f.customGrant == nil {
+		return fmt.Errorf("grant is not correctly initialized")
+	}
+
+	req := make(map[string]interface{})
+
+	if f.GrantType == grantTypePassword {
+
+		req["grant_type"] = grantTypePassword
+		req["username"] = f.passwordGrant.Username
+		req["password"] = f.passwordGrant.Password
+	} else if f.GrantType == grantTypeCustom {
+		g := f.customGrant
+		req = customTokenRequest(g.AccessToken, g.DesiredSubject, g.DesiredExpiresIn, g.DesiredRefreshExpiresIn)
+	}
+
+	return f.requestToken(ctx, req)
+}
+
+// refreshAccessToken Uses the refresh token to get a new access token, falls back on the grant when it can't
+func (f *FTD) refreshAccessToken(ctx context.Context) error {
+	if f.refreshToken == "" || time.Now().After(f.refreshExpiresAt) {
+		return f.updateToken(ctx)
+	}
+
+	req := make(map[string]interface{})
+	req["grant_type"] = grantTypeRefresh
+	req["refresh_token"] = f.refreshToken
+
+	err := f.requestToken(ctx, req)
+	if err != nil {
+		if f.debug {
+			f.logger.Warningf("refresh failed, requesting a new token: %s\n", err)
+		}
+		return f.updateToken(ctx)
+	}
+
+	return nil
+}
+
+// postTokenRequest Posts a grant to the token endpoint and returns the tokens issued
+func (f *FTD) postTokenRequest(ctx context.Context, req map[string]interface{}) (*Token, error) {
+	var t *Token
+
+	data, err := f.PostContext(ctx, apiTokenEndpoint, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &t)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if t.AccessToken == "" {
+		return nil, fmt.Errorf("missing access_token in reply")
+	}
+
+	if t.RefreshToken == "" {
+		return nil, fmt.Errorf("missing refresh_token in reply")
+	}
+
+	if t.ExpiresIn == 0 {
+		return nil, fmt.Errorf("missing expires_in in reply")
+	}
+
+	return t, nil
+}
+
+// requestToken Posts a grant to the token endpoint and stores the tokens returned
+func (f *FTD) requestToken(ctx context.Context, req map[string]interface{}) error {
+	t, err := f.postTokenRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	f.accessToken = t.AccessToken
+	f.refreshToken = t.RefreshToken
+	f.expiresAt = time.Now().Add(time.Second * time.Duration(t.ExpiresIn))
+
+	if t.RefreshExpiresIn > 0 {
+		f.refreshExpiresAt = time.Now().Add(time.Second * time.Duration(t.RefreshExpiresIn))
+	} else {
+		// Unknown lifetime, assume it lives as long as the access token
+		f.refreshExpiresAt = f.expiresAt
+	}
+
+	return nil
+}
+
+// RequestCustomToken Requests a custom token for subject from the current session, e.g. for a service account.
+// A lifetime of 0 lets the device pick its default.
+func (f *FTD) RequestCustomToken(subject string, expiresIn, refreshExpiresIn time.Duration) (*Token, error) {
+	return f.RequestCustomTokenContext(context.Background(), subject, expiresIn, refreshExpiresIn)
+}
+
+// RequestCustomTokenContext Same as RequestCustomToken, ctx cancels the requests
+func (f *FTD) RequestCustomTokenContext(ctx context.Context, subject string, expiresIn, refreshExpiresIn time.Duration) (*Token, error) {
+	token, err := f.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := customTokenRequest(token, subject, int(expiresIn/time.Second), int(refreshExpiresIn/time.Second))
+
+	t, err := f.postTokenRequest(ctx, req)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// token Returns a valid access token, refreshing it when it is about to expire
+func (f *FTD) token(ctx context.Context) (string, error) {
+	f.tokenMutex.Lock()
+	defer f.tokenMutex.Unlock()
+
+	if f.accessToken == "" || time.Now().Add(tokenRefreshMargin).After(f.expiresAt) {
+		err := f.refreshAccessToken(ctx)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return "", err
+		}
+	}
+
+	return f.accessToken, nil
+}
+
+// renewToken Replaces a token rejected by the device, unless another request already did
+func (f *FTD) renewToken(ctx context.Context, stale string) (string, error) {
+	f.tokenMutex.Lock()
+	defer f.tokenMutex.Unlock()
+
+	if f.accessToken != stale && f.accessToken != "" {
+		return f.accessToken, nil
+	}
+
+	err := f.refreshAccessToken(ctx)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return "", err
+	}
+
+	return f.accessToken, nil
+}
+
+func (f *FTD) revokeToken(ctx context.Context, token string) error {
+	req := make(map[string]interface{})
+	req["grant_type"] = grantTypeRevoke
+	req["access_token"] = f.accessToken
+	req["token_to_revoke"] = token
+
+	_, err := f.PostContext(ctx, apiTokenEndpoint, req)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RevokeToken Revokes a token issued by the device
+func (f *FTD) RevokeToken(token string) error {
+	return f.RevokeTokenContext(context.Background(), token)
+}
+
+// RevokeTokenContext Same as RevokeToken, ctx cancels the requests
+func (f *FTD) RevokeTokenContext(ctx context.Context, token string) error {
+	f.tokenMutex.Lock()
+	defer f.tokenMutex.Unlock()
+
+	return f.revokeToken(ctx, token)
+}
+
+// Logout Revokes the tokens of the session, the next request will authenticate again
+func (f *FTD) Logout() error {
+	return f.LogoutContext(context.Background())
+}
+
+// LogoutContext Same as Logout, ctx cancels the requests
+func (f *FTD) LogoutContext(ctx context.Context) error {
+	var err error
+
+	f.tokenMutex.Lock()
+	defer f.tokenMutex.Unlock()
+
+	if f.accessToken == "" {
+		return nil
+	}
+
+	if f.refreshToken != "" {
+		err = f.revokeToken(ctx, f.refreshToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = f.revokeToken(ctx, f.accessToken)
+	if err != nil {
+		return err
+	}
+
+	f.accessToken = ""
+	f.refreshToken = ""
+	f.expiresAt = time.Time{}
+	f.refreshExpiresAt = time.Time{}
+
+	return nil
+}
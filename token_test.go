@@ -0,0 +1,93 @@
+package goftd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshToken(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	old := ftd.accessToken
+	ftd.expiresAt = time.Now()
+
+	_, err = ftd.GetNetworkObjects(1)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if ftd.accessToken == old {
+		t.Errorf("token was not refreshed\n")
+	}
+}
+
+func TestLogout(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	err = ftd.Logout()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if ftd.accessToken != "" {
+		t.Errorf("token was not cleared\n")
+	}
+
+	// The next request authenticates again
+	_, err = ftd.GetNetworkObjects(1)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+}
+
+func TestCustomToken(t *testing.T) {
+	ftd, err := initTest()
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	token, err := ftd.RequestCustomToken("ci-pipeline", time.Hour, 2*time.Hour)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	if token.AccessToken == "" || token.RefreshToken == "" {
+		t.Errorf("token is not populated correctly\n")
+		return
+	}
+
+	params := make(map[string]string)
+	params["grant_type"] = "custom_token"
+	params["access_token"] = token.AccessToken
+	params["desired_subject"] = "ci-pipeline"
+	params["desired_expires_in"] = "600"
+	params["insecure"] = "true"
+
+	ci, err := NewFTD(ftd.Hostname, params)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+		return
+	}
+
+	_, err = ci.GetNetworkObjects(1)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	err = ftd.RevokeToken(token.AccessToken)
+	if err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+}
@@ -0,0 +1,286 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// URLCategoryMatcher Matches a URL category, restricted to a reputation when set
+type URLCategoryMatcher struct {
+	URLCategory   *ReferenceObject `json:"urlCategory,omitempty"`
+	URLReputation *ReferenceObject `json:"urlReputation,omitempty"`
+	Type          string           `json:"type"`
+}
+
+// EmbeddedURLFilter URLs matched by an access rule, listed or selected by category
+type EmbeddedURLFilter struct {
+	URLObjects    []*ReferenceObject    `json:"urlObjects,omitempty"`
+	URLCategories []*URLCategoryMatcher `json:"urlCategories,omitempty"`
+	Type          string                `json:"type"`
+}
+
+// NewEmbeddedURLFilter Returns an empty URL filter, see AddURLObject and AddURLCategory
+func NewEmbeddedURLFilter() *EmbeddedURLFilter {
+	r := EmbeddedURLFilter{
+		Type: "embeddedurlfilter",
+	}
+
+	return &r
+}
+
+// AddURLObject Matches the URLs of u
+func (e *EmbeddedURLFilter) AddURLObject(u *URLObject) {
+	e.URLObjects = append(e.URLObjects, u.Reference())
+}
+
+// AddURLCategory Matches the category c, reputation may be nil to match any reputation
+func (e *EmbeddedURLFilter) AddURLCategory(c *URLCategory, reputation *URLReputation) {
+	m := URLCategoryMatcher{
+		URLCategory: c.Reference(),
+		Type:        "urlcategorymatcher",
+	}
+
+	if reputation != nil {
+		m.URLReputation = reputation.Reference()
+	}
+
+	e.URLCategories = append(e.URLCategories, &m)
+}
+
+// URLCategory A category of URLs, e.g. Gambling
+type URLCategory struct {
+	ReferenceObject
+	CategoryID  int    `json:"categoryId,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (c *URLCategory) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      c.ID,
+		Name:    c.Name,
+		Version: c.Version,
+		Type:    c.Type,
+	}
+
+	return &r
+}
+
+// URLReputation A reputation level of URLs, e.g. High risk
+type URLReputation struct {
+	ReferenceObject
+	Description string `json:"description,omitempty"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (p *URLReputation) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      p.ID,
+		Name:    p.Name,
+		Version: p.Version,
+		Type:    p.Type,
+	}
+
+	return &r
+}
+
+// URLObject A URL or part of a URL, e.g. example.com
+type URLObject struct {
+	ReferenceObject
+	Description     string `json:"description,omitempty"`
+	URL             string `json:"url,omitempty"`
+	IsSystemDefined bool   `json:"isSystemDefined,omitempty"`
+	Links           *Links `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (u *URLObject) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      u.ID,
+		Name:    u.Name,
+		Version: u.Version,
+		Type:    u.Type,
+	}
+
+	return &r
+}
+
+// GetURLCategories Get a list of URL categories, a limit of 0 returns all of them
+func (f *FTD) GetURLCategories(limit int) ([]*URLCategory, error) {
+	return f.GetURLCategoriesContext(context.Background(), limit)
+}
+
+// GetURLCategoriesContext Same as GetURLCategories, ctx cancels the requests
+func (f *FTD) GetURLCategoriesContext(ctx context.Context, limit int) ([]*URLCategory, error) {
+	var err error
+	var retval []*URLCategory
+
+	err = f.iterURLCategories(ctx, limit, func(v *URLCategory) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterURLCategories Calls fn for every URL category, fetching one page at a time
+func (f *FTD) IterURLCategories(ctx context.Context, fn func(*URLCategory) error) error {
+	return f.iterURLCategories(ctx, 0, fn)
+}
+
+func (f *FTD) iterURLCategories(ctx context.Context, limit int, fn func(*URLCategory) error) error {
+	return f.iterate(ctx, apiURLCategoriesEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *URLCategory
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetURLCategoryByName Get a URL category by name
+func (f *FTD) GetURLCategoryByName(name string) (*URLCategory, error) {
+	return f.GetURLCategoryByNameContext(context.Background(), name)
+}
+
+// GetURLCategoryByNameContext Same as GetURLCategoryByName, ctx cancels the requests
+func (f *FTD) GetURLCategoryByNameContext(ctx context.Context, name string) (*URLCategory, error) {
+	var v *URLCategory
+
+	err := f.findByName(ctx, apiURLCategoriesEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetURLReputations Get a list of URL reputations, a limit of 0 returns all of them
+func (f *FTD) GetURLReputations(limit int) ([]*URLReputation, error) {
+	return f.GetURLReputationsContext(context.Background(), limit)
+}
+
+// GetURLReputationsContext Same as GetURLReputations, ctx cancels the requests
+func (f *FTD) GetURLReputationsContext(ctx context.Context, limit int) ([]*URLReputation, error) {
+	var err error
+	var retval []*URLReputation
+
+	err = f.iterURLReputations(ctx, limit, func(v *URLReputation) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterURLReputations Calls fn for every URL reputation, fetching one page at a time
+func (f *FTD) IterURLReputations(ctx context.Context, fn func(*URLReputation) error) error {
+	return f.iterURLReputations(ctx, 0, fn)
+}
+
+func (f *FTD) iterURLReputations(ctx context.Context, limit int, fn func(*URLReputation) error) error {
+	return f.iterate(ctx, apiURLReputationsEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *URLReputation
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetURLReputationByName Get a URL reputation by name
+func (f *FTD) GetURLReputationByName(name string) (*URLReputation, error) {
+	return f.GetURLReputationByNameContext(context.Background(), name)
+}
+
+// GetURLReputationByNameContext Same as GetURLReputationByName, ctx cancels the requests
+func (f *FTD) GetURLReputationByNameContext(ctx context.Context, name string) (*URLReputation, error) {
+	var v *URLReputation
+
+	err := f.findByName(ctx, apiURLReputationsEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetURLObjects Get a list of URL objects, a limit of 0 returns all of them
+func (f *FTD) GetURLObjects(limit int) ([]*URLObject, error) {
+	return f.GetURLObjectsContext(context.Background(), limit)
+}
+
+// GetURLObjectsContext Same as GetURLObjects, ctx cancels the requests
+func (f *FTD) GetURLObjectsContext(ctx context.Context, limit int) ([]*URLObject, error) {
+	var err error
+	var retval []*URLObject
+
+	err = f.iterURLObjects(ctx, limit, func(v *URLObject) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterURLObjects Calls fn for every URL object, fetching one page at a time
+func (f *FTD) IterURLObjects(ctx context.Context, fn func(*URLObject) error) error {
+	return f.iterURLObjects(ctx, 0, fn)
+}
+
+func (f *FTD) iterURLObjects(ctx context.Context, limit int, fn func(*URLObject) error) error {
+	return f.iterate(ctx, apiURLObjectsEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *URLObject
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetURLObjectByName Get a URL object by name
+func (f *FTD) GetURLObjectByName(name string) (*URLObject, error) {
+	return f.GetURLObjectByNameContext(context.Background(), name)
+}
+
+// GetURLObjectByNameContext Same as GetURLObjectByName, ctx cancels the requests
+func (f *FTD) GetURLObjectByNameContext(ctx context.Context, name string) (*URLObject, error) {
+	var v *URLObject
+
+	err := f.findByName(ctx, apiURLObjectsEndpoint, name, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
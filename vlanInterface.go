@@ -0,0 +1,215 @@
+package goftd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// VLANInterface A VLAN interface, routing traffic for switch ports
+type VLANInterface struct {
+	ReferenceObject
+	Description      string         `json:"description,omitempty"`
+	HardwareName     string         `json:"hardwareName,omitempty"`
+	VLANID           int            `json:"vlanId"`
+	MonitorInterface bool           `json:"monitorInterface,omitempty"`
+	IPv4             *InterfaceIPv4 `json:"ipv4,omitempty"`
+	IPv6             *InterfaceIPv6 `json:"ipv6,omitempty"`
+	ManagementOnly   bool           `json:"managementOnly,omitempty"`
+	Mode             string         `json:"mode,omitempty"`
+	MTU              int            `json:"mtu,omitempty"`
+	Enabled          bool           `json:"enabled"`
+	Links            *Links         `json:"links,omitempty"`
+}
+
+// Reference Returns a reference object
+func (v *VLANInterface) Reference() *ReferenceObject {
+	r := ReferenceObject{
+		ID:      v.ID,
+		Name:    v.Name,
+		Version: v.Version,
+		Type:    v.Type,
+	}
+
+	return &r
+}
+
+// GetVLANInterfaces Get a list of VLAN interfaces, a limit of 0 returns all of them
+func (f *FTD) GetVLANInterfaces(limit int) ([]*VLANInterface, error) {
+	return f.GetVLANInterfacesContext(context.Background(), limit)
+}
+
+// GetVLANInterfacesContext Same as GetVLANInterfaces, ctx cancels the requests
+func (f *FTD) GetVLANInterfacesContext(ctx context.Context, limit int) ([]*VLANInterface, error) {
+	var err error
+	var retval []*VLANInterface
+
+	err = f.iterVLANInterfaces(ctx, limit, func(v *VLANInterface) error {
+		retval = append(retval, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// IterVLANInterfaces Calls fn for every VLAN interface, fetching one page at a time
+func (f *FTD) IterVLANInterfaces(ctx context.Context, fn func(*VLANInterface) error) error {
+	return f.iterVLANInterfaces(ctx, 0, fn)
+}
+
+func (f *FTD) iterVLANInterfaces(ctx context.Context, limit int, fn func(*VLANInterface) error) error {
+	return f.iterate(ctx, apiVLANInterfacesEndpoint, nil, limit, func(item json.RawMessage) error {
+		var v *VLANInterface
+
+		err := json.Unmarshal(item, &v)
+		if err != nil {
+			if f.debug {
+				f.logger.Errorf("Error: %s\n", err)
+			}
+			return err
+		}
+
+		return fn(v)
+	})
+}
+
+// GetVLANInterfaceByID Get a VLAN interface by ID
+func (f *FTD) GetVLANInterfaceByID(id string) (*VLANInterface, error) {
+	return f.GetVLANInterfaceByIDContext(context.Background(), id)
+}
+
+// GetVLANInterfaceByIDContext Same as GetVLANInterfaceByID, ctx cancels the requests
+func (f *FTD) GetVLANInterfaceByIDContext(ctx context.Context, id string) (*VLANInterface, error) {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiVLANInterfacesEndpoint, id)
+	data, err := f.GetContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *VLANInterface
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetVLANInterfaceByHardwareName Get a VLAN interface by hardware name, e.g. Vlan100
+func (f *FTD) GetVLANInterfaceByHardwareName(hardwareName string) (*VLANInterface, error) {
+	return f.GetVLANInterfaceByHardwareNameContext(context.Background(), hardwareName)
+}
+
+// GetVLANInterfaceByHardwareNameContext Same as GetVLANInterfaceByHardwareName, ctx cancels the requests
+func (f *FTD) GetVLANInterfaceByHardwareNameContext(ctx context.Context, hardwareName string) (*VLANInterface, error) {
+	var retval *VLANInterface
+
+	err := f.iterVLANInterfaces(ctx, 0, func(v *VLANInterface) error {
+		if v.HardwareName == hardwareName {
+			retval = v
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return nil, err
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("interface %s: %w", hardwareName, ErrNotFound)
+	}
+
+	return retval, nil
+}
+
+// CreateVLANInterface Create a new VLAN interface
+func (f *FTD) CreateVLANInterface(v *VLANInterface) error {
+	return f.CreateVLANInterfaceContext(context.Background(), v)
+}
+
+// CreateVLANInterfaceContext Same as CreateVLANInterface, ctx cancels the requests
+func (f *FTD) CreateVLANInterfaceContext(ctx context.Context, v *VLANInterface) error {
+	var err error
+
+	v.Type = "vlaninterface"
+
+	data, err := f.PostContext(ctx, apiVLANInterfacesEndpoint, v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateVLANInterface Updates a VLAN interface
+func (f *FTD) UpdateVLANInterface(v *VLANInterface) error {
+	return f.UpdateVLANInterfaceContext(context.Background(), v)
+}
+
+// UpdateVLANInterfaceContext Same as UpdateVLANInterface, ctx cancels the requests
+func (f *FTD) UpdateVLANInterfaceContext(ctx context.Context, v *VLANInterface) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiVLANInterfacesEndpoint, v.ID)
+	data, err := f.PutContext(ctx, endpoint, v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteVLANInterface Delete a VLAN interface
+func (f *FTD) DeleteVLANInterface(v *VLANInterface) error {
+	return f.DeleteVLANInterfaceContext(context.Background(), v)
+}
+
+// DeleteVLANInterfaceContext Same as DeleteVLANInterface, ctx cancels the requests
+func (f *FTD) DeleteVLANInterfaceContext(ctx context.Context, v *VLANInterface) error {
+	var err error
+
+	endpoint := fmt.Sprintf("%s/%s", apiVLANInterfacesEndpoint, v.ID)
+	err = f.DeleteContext(ctx, endpoint)
+	if err != nil {
+		if f.debug {
+			f.logger.Errorf("Error: %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}